@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"spt2/internal/batch"
+	"spt2/internal/config"
+	"spt2/internal/jobs"
+	"spt2/internal/pipeline"
+	"spt2/internal/speechclient"
+	"spt2/internal/storage"
+)
+
+// spt2 batch - bir dizindeki veya glob desenine uyan tüm dosyaları deşifre eder
+//
+// KULLANIM:
+//
+//	go run ./cmd batch speeches/                 // dizin (alt dizinler dahil)
+//	go run ./cmd batch "speeches/*.mp3"          // glob deseni
+//	go run ./cmd batch -workers 8 dersler/ ek/   // birden fazla girdi
+//
+// Çıktılar output_dir altında kaynak dizin yapısı korunarak yazılır; sonunda
+// batch-report-<zaman>.json/.txt özet raporu oluşturulur. Başarısız dosya
+// varsa çıkış kodu 1'dir.
+func runBatch(args []string) {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	configPath := fs.String("config", "configs/default.json", "Path to the configuration file.")
+	workers := fs.Int("workers", 0, "Eşzamanlı işlenecek dosya sayısı (varsayılan: batch_workers).")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal("Kullanım: go run ./cmd batch [options] <dizin|glob> [<dizin|glob> ...]")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Config yüklenemedi: %v", err)
+	}
+	if *workers <= 0 {
+		*workers = cfg.BatchWorkers
+	}
+
+	inputs, err := batch.CollectInputs(fs.Args(), cfg.OutputDir)
+	if err != nil {
+		log.Fatalf("Girdiler okunamadı: %v", err)
+	}
+	if len(inputs) == 0 {
+		log.Fatal("İşlenecek ses dosyası bulunamadı")
+	}
+	if err := batch.CheckOutputConflicts(inputs); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	recognizer, err := speechclient.NewRecognizer(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Speech client başlatılamadı: %v", err)
+	}
+	defer recognizer.Close()
+
+	store, err := storage.NewObjectStore(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Storage başlatılamadı: %v", err)
+	}
+	defer store.Close()
+
+	fmt.Printf("=== Toplu Deşifre: %d dosya, %d worker (backend: %s) ===\n\n", len(inputs), *workers, cfg.Backend)
+
+	// her dosya kendi pipeline'ı ile, ilerleme mesajları olmadan işlenir;
+	// iş kayıtları alt dizinlere dağılmasın diye hepsi <output_dir>/jobs altında tutulur
+	jobStore := jobs.NewStore(filepath.Join(cfg.OutputDir, "jobs"))
+	run := func(ctx context.Context, input batch.Input) (*pipeline.Result, error) {
+		fileCfg := *cfg
+		fileCfg.OutputDir = filepath.Join(cfg.OutputDir, input.RelDir)
+
+		p := pipeline.New(&fileCfg, recognizer, store)
+		p.Jobs = jobStore
+		p.Out = nil
+		return p.Run(ctx, input.Path)
+	}
+
+	progress := func(result batch.FileResult, done int, total int) {
+		switch result.Status {
+		case batch.StatusSucceeded:
+			fmt.Printf("[%d/%d] ✅ %s (%.1f sn)\n", done, total, result.Path, result.Elapsed)
+		default:
+			fmt.Printf("[%d/%d] ❌ %s: %s\n", done, total, result.Path, result.Error)
+		}
+	}
+
+	report := batch.Run(ctx, inputs, *workers, run, progress)
+
+	fmt.Printf("\nToplam: %d dosya, %d başarılı, %d başarısız, %d atlandı (%.1f sn)\n",
+		report.Total, report.Succeeded, report.Failed, report.Skipped, report.FinishedAt.Sub(report.StartedAt).Seconds())
+
+	jsonPath, txtPath, err := batch.WriteReport(report, cfg.OutputDir)
+	if err != nil {
+		log.Fatalf("Rapor yazılamadı: %v", err)
+	}
+	fmt.Printf("📄 Rapor: %s, %s\n", jsonPath, txtPath)
+
+	if report.Failed > 0 || report.Skipped > 0 {
+		os.Exit(1)
+	}
+}
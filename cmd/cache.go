@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"spt2/internal/cache"
+	"spt2/internal/config"
+)
+
+// spt2 cache - deşifre sonuç cache'ini yönetir
+//
+// KULLANIM:
+//
+//	go run ./cmd cache list                    // kayıtları listele
+//	go run ./cmd cache inspect 3fa9c1          // kaydın ayrıntıları (anahtarın başı yeterli)
+//	go run ./cmd cache evict 3fa9c1 7be20d     // kayıtları sil
+//	go run ./cmd cache evict -older-than 720h  // 30 günden eski kayıtları sil
+//	go run ./cmd cache evict -all              // cache'i boşalt (bozuk kayıtlar dahil)
+func runCache(args []string) {
+	usage := "Kullanım: go run ./cmd cache [-config path] list|inspect|evict [options]"
+
+	fs := flag.NewFlagSet("cache", flag.ExitOnError)
+	configPath := fs.String("config", "configs/default.json", "Path to the configuration file.")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal(usage)
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Config yüklenemedi: %v", err)
+	}
+	resultCache := cache.New(cfg.CacheDir)
+
+	switch fs.Arg(0) {
+	case "list":
+		cacheList(resultCache)
+	case "inspect":
+		cacheInspect(resultCache, fs.Args()[1:])
+	case "evict":
+		cacheEvict(resultCache, fs.Args()[1:])
+	default:
+		log.Fatal(usage)
+	}
+}
+
+func cacheList(resultCache *cache.Cache) {
+	entries, broken, err := resultCache.List()
+	if err != nil {
+		log.Fatalf("Cache okunamadı: %v", err)
+	}
+	printBrokenEntries(broken)
+	if len(entries) == 0 {
+		fmt.Printf("Cache boş (%s)\n", resultCache.Dir())
+		return
+	}
+
+	for _, entry := range entries {
+		words := 0
+		if entry.Result != nil {
+			words = len(entry.Result.Words)
+		}
+		fmt.Printf("%s  %s  %-6s %-10s %5d kelime  %s\n",
+			cache.ShortKey(entry.Key), entry.CreatedAt.Format("2006-01-02 15:04"),
+			entry.Settings.LanguageCode, entry.Settings.Model, words, entry.AudioFile)
+	}
+	fmt.Printf("\nToplam: %d kayıt (%s)\n", len(entries), resultCache.Dir())
+}
+
+func cacheInspect(resultCache *cache.Cache, keys []string) {
+	if len(keys) != 1 {
+		log.Fatal("Kullanım: go run ./cmd cache inspect <anahtar>")
+	}
+
+	entry, err := resultCache.Find(keys[0])
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	fmt.Printf("Anahtar: %s\n", entry.Key)
+	fmt.Printf("İçerik Hash'i: %s\n", entry.ContentHash)
+	fmt.Printf("Ses Dosyası: %s (%d bytes)\n", entry.AudioFile, entry.AudioSize)
+	fmt.Printf("Oluşturulma: %s\n", entry.CreatedAt.Format("2006-01-02 15:04:05"))
+
+	settings, _ := json.MarshalIndent(entry.Settings, "", "  ")
+	fmt.Printf("\n--- TANIMA AYARLARI ---\n\n%s\n", settings)
+
+	if entry.Result != nil {
+		transcript := []rune(entry.Result.Transcript)
+		if len(transcript) > 300 {
+			transcript = append(transcript[:300], []rune("...")...)
+		}
+		fmt.Printf("\n--- SONUÇ ---\n\n")
+		fmt.Printf("Süre: %.1f sn, %d kelime, güven: %.2f\n\n", entry.Result.AudioDuration, len(entry.Result.Words), entry.Result.Confidence)
+		fmt.Println(string(transcript))
+	}
+}
+
+func cacheEvict(resultCache *cache.Cache, args []string) {
+	fs := flag.NewFlagSet("cache evict", flag.ExitOnError)
+	all := fs.Bool("all", false, "Tüm kayıtları sil.")
+	olderThan := fs.Duration("older-than", 0, "Bu süreden eski kayıtları sil (örn: 720h).")
+	fs.Parse(args)
+
+	var keys []string
+	if *all || *olderThan > 0 {
+		entries, broken, err := resultCache.List()
+		if err != nil {
+			log.Fatalf("Cache okunamadı: %v", err)
+		}
+		if *all {
+			// bozuk dosyaların anahtarı güvenilmez; dosya yoluyla silinir
+			for _, entry := range broken {
+				if err := os.Remove(entry.Path); err != nil {
+					log.Fatalf("Bozuk cache dosyası silinemedi: %v", err)
+				}
+				fmt.Printf("🗑️  %s silindi (bozuk kayıt)\n", filepath.Base(entry.Path))
+			}
+		} else {
+			printBrokenEntries(broken)
+		}
+		cutoff := time.Now().Add(-*olderThan)
+		for _, entry := range entries {
+			if *all || entry.CreatedAt.Before(cutoff) {
+				keys = append(keys, entry.Key)
+			}
+		}
+	} else {
+		if fs.NArg() == 0 {
+			log.Fatal("Kullanım: go run ./cmd cache evict <anahtar>... | -all | -older-than <süre>")
+		}
+		for _, prefix := range fs.Args() {
+			entry, err := resultCache.Find(prefix)
+			if err != nil {
+				log.Fatalf("%v", err)
+			}
+			keys = append(keys, entry.Key)
+		}
+	}
+
+	for _, key := range keys {
+		if err := resultCache.Delete(key); err != nil {
+			log.Fatalf("%v", err)
+		}
+		fmt.Printf("🗑️  %s silindi\n", cache.ShortKey(key))
+	}
+	fmt.Printf("\n%d kayıt silindi\n", len(keys))
+}
+
+// okunamayan cache dosyaları için uyarı
+func printBrokenEntries(broken []*cache.BrokenEntry) {
+	for _, entry := range broken {
+		fmt.Printf("⚠️  Bozuk cache kaydı atlandı: %v\n", entry)
+	}
+	if len(broken) > 0 {
+		fmt.Println()
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"spt2/internal/analysis"
+	"spt2/internal/config"
+	"spt2/internal/output"
+	"spt2/internal/pipeline"
+)
+
+// spt2 export - kaydedilmiş JSON çıktısından diğer formatları yeniden üretir
+//
+// API tekrar çağrılmaz; altyazı bölümleme veya rapor ayarları değiştirildikten
+// sonra çıktıları yenilemek için kullanılır.
+//
+// KULLANIM:
+//
+//	go run ./cmd export output/ders1.json                  // config'te açık formatlar (json hariç)
+//	go run ./cmd export -formats srt output/*.json          // sadece SRT
+//	go run ./cmd export -output yeni/ -config configs/config-tr.json output/ders1.json
+//
+// Bir çıktı kaynak JSON'un kendisine yazılacaksa (örn. -formats json,
+// -output verilmeden) o dosya atlanır ve hata verilir.
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	configPath := fs.String("config", "configs/default.json", "Path to the configuration file.")
+	formatNames := fs.String("formats", "", fmt.Sprintf("Üretilecek formatlar, virgülle ayrılmış (%s). Varsayılan: config'te açık olanlar, json hariç.", strings.Join(output.FormatNames(), ", ")))
+	outputDir := fs.String("output", "", "Çıktı dizini (varsayılan: output_dir).")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal("Kullanım: go run ./cmd export [options] <spt2_json_dosyası> [...]")
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Config yüklenemedi: %v", err)
+	}
+	if *outputDir != "" {
+		cfg.OutputDir = *outputDir
+	}
+
+	var formats []*output.Format
+	if *formatNames != "" {
+		formats, err = output.LookupFormats(*formatNames)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+	} else {
+		// kaynak JSON'un üzerine yazılmaması için json varsayılan olarak üretilmez
+		for _, format := range output.EnabledFormats(cfg) {
+			if format.Name != "json" {
+				formats = append(formats, format)
+			}
+		}
+	}
+	if len(formats) == 0 {
+		log.Fatal("Üretilecek çıktı formatı yok (-formats ile belirtin)")
+	}
+
+	logf := func(format string, args ...any) { fmt.Printf(format, args...) }
+
+	failed := 0
+	for _, jsonPath := range fs.Args() {
+		fmt.Printf("📥 %s okunuyor...\n", jsonPath)
+		result, metadata, err := output.ImportJSON(jsonPath)
+		if err != nil {
+			fmt.Printf("❌ %v\n\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ %s (%d kelime, sürüm %s)\n\n", metadata.AudioFile, len(result.Words), metadata.Version)
+
+		if err := checkOverwrite(jsonPath, metadata.AudioFile, cfg.OutputDir, formats); err != nil {
+			fmt.Printf("❌ %v\n\n", err)
+			failed++
+			continue
+		}
+
+		analysis.Annotate(result, cfg)
+		if _, err := pipeline.ExportFormats(result, metadata.AudioFile, cfg, formats, logf); err != nil {
+			fmt.Printf("❌ %v\n\n", err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// bir format kaynak JSON'un kendisine yazacaksa hata
+//
+// NEDEN: json formatı low_confidence "filter" modunda kelimeleri atar; kaynak
+// dosyanın üzerine yazılırsa atılan kelimeler kalıcı olarak kaybolur.
+func checkOverwrite(jsonPath string, audioFile string, outputDir string, formats []*output.Format) error {
+	source, err := os.Stat(jsonPath)
+	if err != nil {
+		return fmt.Errorf("kaynak JSON okunamadı: %w", err)
+	}
+	for _, format := range formats {
+		target := output.OutputPath(audioFile, outputDir, format.Name)
+		if info, err := os.Stat(target); err == nil && os.SameFile(source, info) {
+			return fmt.Errorf("%s çıktısı kaynak dosyanın üzerine yazılacak (%s); -output ile başka bir dizin verin", strings.ToUpper(format.Name), target)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"spt2/internal/fakespeech"
+)
+
+// Google Speech API yerine kullanılabilecek yerel sahte sunucu
+//
+// KULLANIM:
+//
+//	go run ./cmd/fakespeech -addr 127.0.0.1:9090
+//	APP_SPEECH_ENDPOINT=127.0.0.1:9090 go run ./cmd <audio_file_path>
+func main() {
+	addr := flag.String("addr", "127.0.0.1:9090", "Dinlenecek adres.")
+	fixtureDir := flag.String("fixtures", "internal/fakespeech/testdata", "Fixture JSON dosyalarının bulunduğu dizin.")
+	flag.Parse()
+
+	srv, err := fakespeech.Start(*addr, *fixtureDir)
+	if err != nil {
+		log.Fatalf("Fake speech sunucusu başlatılamadı: %v", err)
+	}
+	defer srv.Stop()
+
+	fmt.Printf("🧪 Fake Speech API dinleniyor: %s (fixtures: %s)\n", srv.Addr(), *fixtureDir)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	fmt.Println("\n👋 Sunucu kapatılıyor...")
+}
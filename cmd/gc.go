@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"spt2/internal/config"
+	"spt2/internal/storage"
+)
+
+// spt2 gc - araç tarafından yüklenen objeleri listeler ve süresi dolanları siler
+//
+// KULLANIM:
+//
+//	go run ./cmd gc -dry-run          // sadece listele
+//	go run ./cmd gc                   // süresi dolanları sil
+//	go run ./cmd gc -all              // araç objelerinin hepsini sil
+//	go run ./cmd gc -older-than 48h   // metadata'sız objeler için yaş sınırı
+func runGC(args []string) {
+	fs := flag.NewFlagSet("gc", flag.ExitOnError)
+	configPath := fs.String("config", "configs/default.json", "Path to the configuration file.")
+	dryRun := fs.Bool("dry-run", false, "Silmeden sadece listele.")
+	all := fs.Bool("all", false, "Saklama süresine bakmadan araç objelerinin hepsini sil.")
+	olderThan := fs.Duration("older-than", 0, "expires-at metadata'sı olmayan objeler için yaş sınırı (varsayılan: retention_days).")
+	fs.Parse(args)
+
+	ctx := context.Background()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Config yüklenemedi: %v", err)
+	}
+
+	if *olderThan == 0 {
+		*olderThan = time.Duration(cfg.RetentionDays) * 24 * time.Hour
+	}
+
+	store, err := storage.NewObjectStore(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Storage başlatılamadı: %v", err)
+	}
+	defer store.Close()
+
+	entries, err := storage.CollectGarbage(ctx, store, storage.GCOptions{
+		All:       *all,
+		OlderThan: *olderThan,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		log.Fatalf("Objeler listelenemedi: %v", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("Araç tarafından yüklenmiş obje bulunamadı.")
+		return
+	}
+
+	var purged, failed int
+	var freed int64
+	for _, entry := range entries {
+		status := "  sakla"
+		switch {
+		case entry.Err != nil:
+			status = "❌ hata"
+			failed++
+		case entry.Deleted:
+			status = "🗑️  silindi"
+			purged++
+			freed += entry.Object.Size
+		case entry.Purge:
+			status = "🗑️  silinecek"
+			purged++
+			freed += entry.Object.Size
+		}
+
+		fmt.Printf("%-12s %s (%d bytes) - %s\n", status, entry.Object.URI, entry.Object.Size, entry.Reason)
+		if entry.Err != nil {
+			fmt.Printf("             %v\n", entry.Err)
+		}
+	}
+
+	fmt.Printf("\nToplam: %d obje, %d silinecek/silindi (%d bytes), %d hata\n", len(entries), purged, freed, failed)
+	if *dryRun {
+		fmt.Println("(dry-run: hiçbir obje silinmedi)")
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"spt2/internal/config"
+	"spt2/internal/jobs"
+	"spt2/internal/pipeline"
+	"spt2/internal/speechclient"
+	"spt2/internal/storage"
+)
+
+// spt2 resume - yarıda kalan işleri kaldıkları aşamadan sürdürür
+//
+// KULLANIM:
+//
+//	go run ./cmd resume                       // tamamlanmamış işleri listele
+//	go run ./cmd resume 20240610-150405-ders1 // tek bir işi sürdür
+//	go run ./cmd resume -all                  // tamamlanmamış işlerin hepsini sürdür
+//
+// Long-running işlem başlatılmışsa yeniden gönderilmez; kayıtlı işlem adıyla
+// LongRunningRecognizeOperation üzerinden sonuca bağlanılır.
+func runResume(args []string) {
+	fs := flag.NewFlagSet("resume", flag.ExitOnError)
+	configPath := fs.String("config", "configs/default.json", "Path to the configuration file.")
+	all := fs.Bool("all", false, "Tamamlanmamış işlerin hepsini sürdür.")
+	fs.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Config yüklenemedi: %v", err)
+	}
+
+	jobStore := jobs.NewStore(filepath.Join(cfg.OutputDir, "jobs"))
+	manifests, err := jobStore.List()
+	if err != nil {
+		log.Fatalf("İş kayıtları okunamadı: %v", err)
+	}
+
+	jobIDs := fs.Args()
+	if *all {
+		jobIDs = nil
+		for _, manifest := range manifests {
+			if !manifest.Done() {
+				jobIDs = append(jobIDs, manifest.ID)
+			}
+		}
+	}
+
+	if len(jobIDs) == 0 {
+		printPendingJobs(manifests)
+		return
+	}
+
+	recognizer, err := speechclient.NewRecognizer(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Speech client başlatılamadı: %v", err)
+	}
+	defer recognizer.Close()
+
+	store, err := storage.NewObjectStore(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Storage başlatılamadı: %v", err)
+	}
+	defer store.Close()
+
+	p := pipeline.New(cfg, recognizer, store)
+	p.Jobs = jobStore
+
+	failed := 0
+	for _, jobID := range jobIDs {
+		if _, err := p.Resume(ctx, jobID); err != nil {
+			fmt.Printf("❌ %s: %v\n\n", jobID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("\n✅ %s tamamlandı\n\n", jobID)
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// tamamlanmamış işleri tablo olarak yazdırma
+func printPendingJobs(manifests []*jobs.Manifest) {
+	pending := 0
+	for _, manifest := range manifests {
+		if manifest.Done() {
+			continue
+		}
+		pending++
+
+		fmt.Printf("%-40s %-11s %s\n", manifest.ID, manifest.Stage, manifest.AudioFile)
+		if manifest.OperationName != "" {
+			fmt.Printf("%-40s işlem: %s\n", "", manifest.OperationName)
+		}
+		if len(manifest.Chunks) > 0 {
+			fmt.Printf("%-40s parçalar: %d/%d tamamlandı\n", "", manifest.ChunksDone(), len(manifest.Chunks))
+		}
+		if manifest.Error != "" {
+			fmt.Printf("%-40s hata: %s\n", "", manifest.Error)
+		}
+	}
+
+	if pending == 0 {
+		fmt.Println("Tamamlanmamış iş yok.")
+		return
+	}
+	fmt.Printf("\n%d tamamlanmamış iş. Sürdürmek için: go run ./cmd resume <id> (veya -all)\n", pending)
+}
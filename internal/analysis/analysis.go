@@ -0,0 +1,75 @@
+// Package analysis, deşifre sonucundan türetilen analizleri (konuşmacı
+// istatistikleri, anahtar kelime eşleşmeleri, düşük güvenli kelimeler) hesaplar.
+//
+// Sonuçlar API yanıtından değil kelime listesinden hesaplandığı için cache'ten
+// gelen veya kaydedilmiş JSON'dan okunan sonuçlara da aynı şekilde uygulanır.
+package analysis
+
+import (
+	"sort"
+
+	"spt2/internal/keywords"
+	"spt2/internal/language"
+	"spt2/pkg/models"
+)
+
+// Annotate - config'te açık olan analizleri sonuca ekleme
+//
+// KULLANIM:
+//
+//	analysis.Annotate(result, cfg) // çıktılar üretilmeden önce
+func Annotate(result *models.TranscriptionResult, cfg *models.AppConfig) {
+	FlagLowConfidence(result.Words, cfg)
+	if cfg.EnableDiarization || cfg.MultiChannel {
+		result.Speakers = Speakers(result.Words)
+	}
+	if cfg.MultiChannel {
+		NameChannels(result.Speakers, cfg.ChannelNames)
+	}
+	result.KeywordMatches = matchKeywords(result, cfg)
+}
+
+// anahtar kelime eşleşmeleri
+//
+// language_code "auto" ise her aday dil için keywords_file + dil profilinin
+// keywords'ü o dilin kurallarıyla aranır ve yalnızca o dilde tespit edilen
+// segmentlerdeki eşleşmeler tutulur (karışık Türkçe/İngilizce derslerde her
+// bölüm kendi kelime listesiyle taranır).
+func matchKeywords(result *models.TranscriptionResult, cfg *models.AppConfig) []models.KeywordMatch {
+	if cfg.LanguageCode != language.Auto {
+		if len(cfg.Keywords) == 0 {
+			return nil
+		}
+		return keywords.NewMatcher(cfg.Keywords, keywords.OptionsFromConfig(cfg)).Match(result.Words)
+	}
+
+	var matches []models.KeywordMatch
+	for _, code := range language.Candidates(cfg) {
+		profile, _ := language.Profile(cfg, code)
+		terms := append(append([]string(nil), cfg.Keywords...), profile.Keywords...)
+		if len(terms) == 0 {
+			continue
+		}
+
+		languageCfg := *cfg
+		languageCfg.LanguageCode = code
+		for _, match := range keywords.NewMatcher(terms, keywords.OptionsFromConfig(&languageCfg)).Match(result.Words) {
+			if segmentLanguage(result, match.Timestamp) == code {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].Timestamp < matches[b].Timestamp })
+	return matches
+}
+
+// zamanın düştüğü segmentin dili; segmentte dil bilgisi yoksa sonucun dili
+func segmentLanguage(result *models.TranscriptionResult, timestamp float64) string {
+	for _, segment := range result.Segments {
+		if segment.LanguageCode != "" && timestamp >= segment.Start && timestamp < segment.End {
+			return segment.LanguageCode
+		}
+	}
+	return result.LanguageCode
+}
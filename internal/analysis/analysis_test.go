@@ -0,0 +1,61 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+// auto modunda her segment kendi dilinin kelime listesiyle ve kurallarıyla taranmalı
+func TestAnnotateKeywordsPerSegmentLanguage(t *testing.T) {
+	cfg := &models.AppConfig{
+		LanguageCode:             "auto",
+		AlternativeLanguageCodes: []string{"tr-TR", "en-US"},
+		Keywords:                 []string{"API"},
+		LanguageProfiles: map[string]models.LanguageProfile{
+			"tr-TR": {Keywords: []string{"sınav"}},
+			"en-US": {Keywords: []string{"exam"}},
+		},
+	}
+	result := &models.TranscriptionResult{
+		LanguageCode: "tr-TR",
+		Words: []models.WordInfo{
+			{Word: "API", StartTime: 0, EndTime: 1},
+			{Word: "sınavda", StartTime: 1, EndTime: 2},
+			{Word: "exam", StartTime: 2, EndTime: 3}, // Türkçe segmentte
+			{Word: "the", StartTime: 10, EndTime: 11},
+			{Word: "exam", StartTime: 11, EndTime: 12},
+			{Word: "sınav", StartTime: 12, EndTime: 13}, // İngilizce segmentte
+		},
+		Segments: []models.Segment{
+			{LanguageCode: "tr-TR", Start: 0, End: 10},
+			{LanguageCode: "en-US", Start: 10, End: 20},
+		},
+	}
+	Annotate(result, cfg)
+
+	var found []string
+	for _, match := range result.KeywordMatches {
+		found = append(found, fmt.Sprintf("%s@%.0f", match.Keyword, match.Timestamp))
+	}
+	if got := fmt.Sprint(found); got != "[API@0 sınav@1 exam@11]" {
+		t.Errorf("eşleşmeler = %s", got)
+	}
+}
+
+func TestSegmentLanguage(t *testing.T) {
+	result := &models.TranscriptionResult{
+		LanguageCode: "tr-TR",
+		Segments: []models.Segment{
+			{LanguageCode: "en-US", Start: 0, End: 5},
+			{Start: 5, End: 8}, // dil bilgisi yok
+		},
+	}
+	tests := map[float64]string{0: "en-US", 4.9: "en-US", 5: "tr-TR", 9: "tr-TR"}
+	for timestamp, want := range tests {
+		if got := segmentLanguage(result, timestamp); got != want {
+			t.Errorf("segmentLanguage(%.1f) = %s, beklenen %s", timestamp, got, want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package analysis
+
+import "spt2/pkg/models"
+
+// FlagLowConfidence - güveni min_confidence'ın altındaki kelimeleri işaretleme
+//
+// Kelime güveni yalnızca enable_word_confidence açıkken gelir; kapalıysa
+// (veya min_confidence 0 ise) tüm işaretler kaldırılır. Kaydedilmiş JSON'dan
+// yeniden üretimde güncel config geçerli olsun diye işaret her seferinde
+// yeniden hesaplanır.
+func FlagLowConfidence(words []models.WordInfo, cfg *models.AppConfig) {
+	enabled := cfg.EnableWordConfidence && cfg.MinConfidence > 0
+	for i := range words {
+		words[i].LowConfidence = enabled && words[i].Confidence < cfg.MinConfidence
+	}
+}
@@ -0,0 +1,183 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"spt2/pkg/models"
+)
+
+// önceki konuşmacının cümlesi bitmeden bu süreden kısa boşlukla başlayan
+// konuşma sırası söz kesme sayılır (saniye)
+const interruptionGap = 0.3
+
+// Turn - tek konuşmacının kesintisiz konuşma sırası
+type Turn struct {
+	SpeakerTag int32
+	Start      float64
+	End        float64
+	Words      []models.WordInfo
+}
+
+// Duration - konuşma sırasının süresi (ilk kelimenin başından son kelimenin sonuna)
+func (turn Turn) Duration() float64 {
+	return turn.End - turn.Start
+}
+
+// Turns - kelimeleri konuşmacı değişimlerinden konuşma sıralarına bölme
+//
+// Konuşmacı etiketi olmayan (SpeakerTag 0) kelimeler hiçbir sıraya eklenmez;
+// hiç etiket yoksa nil döner.
+func Turns(words []models.WordInfo) []Turn {
+	var turns []Turn
+	for _, word := range words {
+		if word.SpeakerTag == 0 {
+			continue
+		}
+		if last := len(turns) - 1; last >= 0 && turns[last].SpeakerTag == word.SpeakerTag {
+			turns[last].Words = append(turns[last].Words, word)
+			if word.EndTime > turns[last].End {
+				turns[last].End = word.EndTime
+			}
+			continue
+		}
+		turns = append(turns, Turn{
+			SpeakerTag: word.SpeakerTag,
+			Start:      word.StartTime,
+			End:        word.EndTime,
+			Words:      []models.WordInfo{word},
+		})
+	}
+	return turns
+}
+
+// IsDiarizationSummary - words, previous'taki kelimeleri konuşmacı
+// etiketleriyle baştan tekrarlıyor mu
+//
+// Diarization açıkken API son sonuçta o ana kadarki tüm kelimeleri etiketli
+// olarak yeniden gönderir; bu sonuç diğerlerine eklenirse her kelime iki kez sayılır.
+func IsDiarizationSummary(words []models.WordInfo, previous []models.WordInfo) bool {
+	if len(previous) == 0 || len(words) < len(previous) {
+		return false
+	}
+	for _, word := range words {
+		if word.SpeakerTag == 0 {
+			return false
+		}
+	}
+	return words[0].StartTime <= previous[0].StartTime
+}
+
+// kelime listesinin sonuna eklenmiş diarization özetini ayıklama
+//
+// Özeti ayıklamayan sürümlerin kaydettiği JSON'lar (export) veya fixture'lar
+// için: zaman geriye sıçrıyor ve sonrası öncesini etiketli tekrarlıyorsa
+// yalnızca özet kullanılır.
+func speakerWords(words []models.WordInfo) []models.WordInfo {
+	for i := 1; i < len(words); i++ {
+		if words[i].StartTime < words[i-1].StartTime && IsDiarizationSummary(words[i:], words[:i]) {
+			return words[i:]
+		}
+	}
+	return words
+}
+
+// Speakers - diarization etiketlerinden konuşmacı bazlı istatistikler
+//
+// KURALLAR:
+//   - konuşma süresi, konuşmacının sıralarının süreleri toplamıdır
+//     (sıra içindeki kısa duraklamalar dahil)
+//   - dakikada kelime = kelime sayısı / konuşma süresi
+//   - söz kesme: önceki sıra cümle sonu noktalamasıyla bitmeden, üst üste
+//     binerek veya interruptionGap'ten kısa boşlukla başlayan sıra
+//   - konuşma oranı, konuşmacının süresinin tüm konuşmacıların toplamına oranıdır
+//   - etiketsiz (SpeakerTag 0) kelimeler hiçbir konuşmacıya sayılmaz
+//   - listede API'nin diarization özeti de varsa kelimeler bir kez sayılır
+//
+// Kelimelerde konuşmacı etiketi yoksa nil döner. Sonuç SpeakerTag'e göre sıralıdır.
+func Speakers(words []models.WordInfo) []models.SpeakerInfo {
+	turns := Turns(speakerWords(words))
+
+	stats := make(map[int32]*models.SpeakerInfo)
+	texts := make(map[int32][]string)
+	var totalDuration float64
+
+	for i, turn := range turns {
+		speaker, ok := stats[turn.SpeakerTag]
+		if !ok {
+			speaker = &models.SpeakerInfo{SpeakerTag: turn.SpeakerTag}
+			stats[turn.SpeakerTag] = speaker
+		}
+
+		duration := turn.Duration()
+		speaker.TotalDuration += duration
+		speaker.WordCount += len(turn.Words)
+		speaker.Turns++
+		if duration > speaker.LongestMonologue {
+			speaker.LongestMonologue = duration
+			speaker.LongestMonologueStart = turn.Start
+		}
+		totalDuration += duration
+
+		if i > 0 && interrupts(turns[i-1], turn) {
+			speaker.Interruptions++
+			if previous, ok := stats[turns[i-1].SpeakerTag]; ok {
+				previous.Interrupted++
+			}
+		}
+
+		for _, word := range turn.Words {
+			texts[turn.SpeakerTag] = append(texts[turn.SpeakerTag], word.Word)
+		}
+	}
+
+	if len(stats) == 0 {
+		return nil
+	}
+
+	speakers := make([]models.SpeakerInfo, 0, len(stats))
+	for tag, speaker := range stats {
+		if speaker.TotalDuration > 0 {
+			speaker.WordsPerMinute = float64(speaker.WordCount) / (speaker.TotalDuration / 60)
+		}
+		if totalDuration > 0 {
+			speaker.TalkRatio = speaker.TotalDuration / totalDuration
+		}
+		speaker.Transcript = strings.Join(texts[tag], " ")
+		speakers = append(speakers, *speaker)
+	}
+	sort.Slice(speakers, func(i, j int) bool { return speakers[i].SpeakerTag < speakers[j].SpeakerTag })
+
+	return speakers
+}
+
+// sıra, önceki konuşmacının sözünü kesiyor mu
+func interrupts(previous Turn, turn Turn) bool {
+	if previous.SpeakerTag == turn.SpeakerTag {
+		return false
+	}
+	if turn.Start < previous.End {
+		return true
+	}
+	lastWord := previous.Words[len(previous.Words)-1].Word
+	return turn.Start-previous.End < interruptionGap && !EndsSentence(lastWord)
+}
+
+// EndsSentence - kelime cümle sonu noktalamasıyla (. ? ! …) bitiyor mu;
+// kapanan tırnak ve parantezler yok sayılır
+func EndsSentence(word string) bool {
+	word = strings.TrimRight(word, "\"'”’)»")
+	return strings.HasSuffix(word, ".") || strings.HasSuffix(word, "?") ||
+		strings.HasSuffix(word, "!") || strings.HasSuffix(word, "…")
+}
+
+// NameChannels - çok kanallı modda konuşmacılara (kanal N = SpeakerTag N)
+// channel_names'teki adları verme
+func NameChannels(speakers []models.SpeakerInfo, names []string) {
+	for i := range speakers {
+		index := int(speakers[i].SpeakerTag) - 1
+		if index >= 0 && index < len(names) {
+			speakers[i].Name = names[index]
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+func word(text string, start float64, end float64, speaker int32) models.WordInfo {
+	return models.WordInfo{Word: text, StartTime: start, EndTime: end, Confidence: 0.9, SpeakerTag: speaker}
+}
+
+func speakerByTag(t *testing.T, speakers []models.SpeakerInfo, tag int32) models.SpeakerInfo {
+	t.Helper()
+	for _, speaker := range speakers {
+		if speaker.SpeakerTag == tag {
+			return speaker
+		}
+	}
+	t.Fatalf("%d. konuşmacı bulunamadı: %v", tag, speakers)
+	return models.SpeakerInfo{}
+}
+
+func TestSpeakersStatistics(t *testing.T) {
+	words := []models.WordInfo{
+		word("Merhaba", 0, 0.5, 1),
+		word("hoş", 0.6, 1.0, 1),
+		word("geldiniz.", 1.0, 2.0, 1),
+		word("Teşekkürler", 2.5, 3.5, 2),
+		word("ben", 3.6, 4.0, 2),
+		word("de", 4.1, 4.5, 1), // cümle bitmeden 0.1 sn sonra: söz kesme
+		word("evet.", 4.6, 5.0, 1),
+	}
+
+	speakers := Speakers(words)
+	if len(speakers) != 2 {
+		t.Fatalf("konuşmacı sayısı = %d, beklenen 2", len(speakers))
+	}
+
+	first := speakerByTag(t, speakers, 1)
+	if first.WordCount != 5 || first.Turns != 2 {
+		t.Errorf("1. konuşmacı: %d kelime, %d sıra; beklenen 5, 2", first.WordCount, first.Turns)
+	}
+	if math.Abs(first.TotalDuration-2.9) > 1e-9 {
+		t.Errorf("1. konuşmacının süresi = %.2f, beklenen 2.90", first.TotalDuration)
+	}
+	if first.Interruptions != 1 || first.Interrupted != 0 {
+		t.Errorf("1. konuşmacı: %d söz kesme, %d kesilme; beklenen 1, 0", first.Interruptions, first.Interrupted)
+	}
+	if first.Transcript != "Merhaba hoş geldiniz. de evet." {
+		t.Errorf("1. konuşmacının metni = %q", first.Transcript)
+	}
+
+	second := speakerByTag(t, speakers, 2)
+	if second.Interruptions != 0 || second.Interrupted != 1 {
+		t.Errorf("2. konuşmacı: %d söz kesme, %d kesilme; beklenen 0, 1", second.Interruptions, second.Interrupted)
+	}
+	if math.Abs(first.TalkRatio+second.TalkRatio-1) > 1e-9 {
+		t.Errorf("konuşma oranlarının toplamı = %.2f", first.TalkRatio+second.TalkRatio)
+	}
+}
+
+// etiketsiz kelimeler ilk etiketli konuşmacının sırasına katılmamalı
+func TestSpeakersSkipsUntaggedWords(t *testing.T) {
+	words := []models.WordInfo{
+		word("etiketsiz", 0, 5, 0),
+		word("bir", 10, 10.5, 1),
+		word("iki", 10.5, 11, 1),
+		word("yine", 11, 20, 0),
+	}
+
+	speakers := Speakers(words)
+	if len(speakers) != 1 {
+		t.Fatalf("konuşmacı sayısı = %d, beklenen 1", len(speakers))
+	}
+	if speakers[0].WordCount != 2 || speakers[0].TotalDuration != 1 {
+		t.Errorf("konuşmacı: %d kelime, %.1f sn; beklenen 2 kelime, 1.0 sn", speakers[0].WordCount, speakers[0].TotalDuration)
+	}
+	if speakers[0].Transcript != "bir iki" {
+		t.Errorf("metin = %q", speakers[0].Transcript)
+	}
+}
+
+// API'nin son sonuçta tekrarladığı etiketli kelimeler iki kez sayılmamalı
+func TestSpeakersIgnoresDiarizationSummary(t *testing.T) {
+	words := []models.WordInfo{
+		word("bir", 0, 1, 0),
+		word("iki", 1, 2, 0),
+		word("üç", 2, 3, 0),
+		word("bir", 0, 1, 1),
+		word("iki", 1, 2, 1),
+		word("üç", 2, 3, 2),
+	}
+
+	speakers := Speakers(words)
+	if len(speakers) != 2 {
+		t.Fatalf("konuşmacı sayısı = %d, beklenen 2", len(speakers))
+	}
+	if first := speakerByTag(t, speakers, 1); first.WordCount != 2 || first.TotalDuration != 2 {
+		t.Errorf("1. konuşmacı: %d kelime, %.1f sn; beklenen 2 kelime, 2.0 sn", first.WordCount, first.TotalDuration)
+	}
+	if second := speakerByTag(t, speakers, 2); second.WordCount != 1 || second.Turns != 1 {
+		t.Errorf("2. konuşmacı: %d kelime, %d sıra; beklenen 1, 1", second.WordCount, second.Turns)
+	}
+}
+
+func TestSpeakersWithoutTags(t *testing.T) {
+	words := []models.WordInfo{word("bir", 0, 1, 0), word("iki", 1, 2, 0)}
+	if speakers := Speakers(words); speakers != nil {
+		t.Errorf("etiket yokken konuşmacılar = %v, beklenen nil", speakers)
+	}
+}
+
+func TestNameChannels(t *testing.T) {
+	speakers := []models.SpeakerInfo{{SpeakerTag: 1}, {SpeakerTag: 2}, {SpeakerTag: 3}}
+	NameChannels(speakers, []string{"Muhabir", "Konuk"})
+
+	if speakers[0].Name != "Muhabir" || speakers[1].Name != "Konuk" || speakers[2].Name != "" {
+		t.Errorf("kanal adları = %q, %q, %q", speakers[0].Name, speakers[1].Name, speakers[2].Name)
+	}
+}
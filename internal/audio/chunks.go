@@ -0,0 +1,133 @@
+package audio
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"spt2/pkg/models"
+)
+
+// Chunk - uzun bir sesin ayrı tanınan parçası
+//
+// Start/End parçanın kaynak sesteki aralığıdır ve komşu parçalarla
+// chunk_overlap kadar örtüşür. KeepStart/KeepEnd bölme noktalarıdır:
+// birleştirmede her kelime, ortası hangi parçanın Keep aralığına düşüyorsa
+// o parçadan alınır.
+type Chunk struct {
+	Index     int     `json:"index"`
+	Start     float64 `json:"start"`
+	End       float64 `json:"end"`
+	KeepStart float64 `json:"keep_start"`
+	KeepEnd   float64 `json:"keep_end"`
+	Path      string  `json:"path"` // parçanın FLAC dosyası
+}
+
+// Duration - parçanın süresi (örtüşme dahil)
+func (c Chunk) Duration() float64 {
+	return c.End - c.Start
+}
+
+// ChunkingEnabled - uzun seslerin parçalara bölünmesi açık mı (config: chunking)
+func ChunkingEnabled(cfg *models.AppConfig) bool {
+	return cfg.Chunking != "never"
+}
+
+// ShouldChunk - ses parçalara bölünerek mi tanınacak
+//
+// KURALLAR (config: chunking):
+// - "never": bölünmez; 500MB ve 480 dakika limitleri geçerlidir
+// - "auto":  FLAC'in süresi chunk_max_duration'ı aşıyorsa bölünür
+func ShouldChunk(metadata *models.AudioMetadata, cfg *models.AppConfig) bool {
+	return ChunkingEnabled(cfg) && ConvertedDuration(metadata) > cfg.ChunkMaxDuration
+}
+
+// ConvertedDuration - FLAC'in süresi; header'da yoksa (veya eski iş
+// kayıtlarında) kaynak sesin süresi
+func ConvertedDuration(metadata *models.AudioMetadata) float64 {
+	if metadata.ConvertedDuration > 0 {
+		return metadata.ConvertedDuration
+	}
+	return metadata.Duration
+}
+
+// PlanChunks - sesi sessizliklerden bölerek parça planı çıkarma
+//
+// Kalan süre gereken en az parça sayısına eşit bölünür ve her bölme noktası
+// hedefe en yakın sessizliğin ortası olur. Sessizlik, önceki noktadan sonraki
+// [maxDuration/2, maxDuration-2*overlap] penceresinde aranır; pencerede
+// sessizlik yoksa doğrudan hedeften bölünür. Parçalar bölme noktasının iki
+// yanında overlap kadar örtüşür, böylece hiçbir parça örtüşme dahil
+// maxDuration'ı aşmaz.
+func PlanChunks(duration float64, silences []Silence, maxDuration float64, overlap float64) []Chunk {
+	step := maxDuration - 2*overlap
+
+	var cuts []float64
+	position := 0.0
+	for duration-position > maxDuration-overlap {
+		remaining := math.Ceil((duration - position - overlap) / step)
+		target := position + (duration-position)/remaining
+		windowStart := position + maxDuration/2
+		windowEnd := position + step
+
+		cut := math.Min(target, windowEnd)
+		nearest := math.Inf(1)
+		for _, silence := range silences {
+			middle := (silence.Start + silence.End) / 2
+			if middle > windowStart && middle <= windowEnd && math.Abs(middle-target) < nearest {
+				cut = middle
+				nearest = math.Abs(middle - target)
+			}
+		}
+
+		cuts = append(cuts, cut)
+		position = cut
+	}
+
+	chunks := make([]Chunk, 0, len(cuts)+1)
+	keepStart := 0.0
+	for i := 0; i <= len(cuts); i++ {
+		keepEnd := duration
+		if i < len(cuts) {
+			keepEnd = cuts[i]
+		}
+		chunks = append(chunks, Chunk{
+			Index:     i,
+			Start:     math.Max(0, keepStart-overlap),
+			End:       math.Min(duration, keepEnd+overlap),
+			KeepStart: keepStart,
+			KeepEnd:   keepEnd,
+		})
+		keepStart = keepEnd
+	}
+
+	return chunks
+}
+
+// ChunkPath - parçanın FLAC yolu (kaynak FLAC'in yanında: kayit.part-001.flac)
+func ChunkPath(flacPath string, index int) string {
+	base := strings.TrimSuffix(flacPath, filepath.Ext(flacPath))
+	return fmt.Sprintf("%s.part-%03d.flac", base, index+1)
+}
+
+// ExtractChunk - parçanın aralığını kaynak FLAC'ten chunk.Path'e kesme
+//
+// Kanal düzeni ve örnekleme hızı kaynak FLAC'le aynı kalır.
+func ExtractChunk(flacPath string, chunk Chunk) error {
+	args := []string{"-ss", formatSeconds(chunk.Start), "-t", formatSeconds(chunk.Duration()),
+		"-i", flacPath, "-c:a", "flac", "-sample_fmt", "s16", "-y", chunk.Path}
+
+	cmd := exec.Command("ffmpeg", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("FFmpeg parça hatası (%d. parça): %w\nÇıktı: %s", chunk.Index+1, err, string(output))
+	}
+	return nil
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
@@ -0,0 +1,95 @@
+package audio
+
+import (
+	"math"
+	"testing"
+)
+
+// parça planının genel kuralları: sınır, süreklilik ve örtüşme
+func checkChunks(t *testing.T, chunks []Chunk, duration float64, maxDuration float64, overlap float64) {
+	t.Helper()
+	if len(chunks) == 0 {
+		t.Fatal("parça planı boş")
+	}
+	if chunks[0].KeepStart != 0 || chunks[len(chunks)-1].KeepEnd != duration {
+		t.Errorf("plan sesi kapsamıyor: %.1f-%.1f", chunks[0].KeepStart, chunks[len(chunks)-1].KeepEnd)
+	}
+	for i, chunk := range chunks {
+		if chunk.Index != i {
+			t.Errorf("%d. parçanın sırası %d", i, chunk.Index)
+		}
+		if chunk.Duration() > maxDuration+1e-9 {
+			t.Errorf("%d. parça %.1f sn, sınır %.1f", i, chunk.Duration(), maxDuration)
+		}
+		if want := math.Max(0, chunk.KeepStart-overlap); chunk.Start != want {
+			t.Errorf("%d. parçanın başı %.1f, beklenen %.1f", i, chunk.Start, want)
+		}
+		if want := math.Min(duration, chunk.KeepEnd+overlap); chunk.End != want {
+			t.Errorf("%d. parçanın sonu %.1f, beklenen %.1f", i, chunk.End, want)
+		}
+		if i > 0 && chunk.KeepStart != chunks[i-1].KeepEnd {
+			t.Errorf("%d. parça öncekine bitişik değil: %.1f != %.1f", i, chunk.KeepStart, chunks[i-1].KeepEnd)
+		}
+	}
+}
+
+func TestPlanChunksShortAudio(t *testing.T) {
+	chunks := PlanChunks(600, nil, 1800, 1)
+	if len(chunks) != 1 {
+		t.Fatalf("parça sayısı = %d, beklenen 1", len(chunks))
+	}
+	checkChunks(t, chunks, 600, 1800, 1)
+}
+
+func TestPlanChunksWithoutSilences(t *testing.T) {
+	duration := 4 * 3600.0
+	chunks := PlanChunks(duration, nil, 1800, 1)
+	checkChunks(t, chunks, duration, 1800, 1)
+
+	// 14400 sn, 1798 sn'lik adımlarla en az 9 parça; eşit bölünmeli
+	if len(chunks) != 9 {
+		t.Fatalf("parça sayısı = %d, beklenen 9", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if keep := chunk.KeepEnd - chunk.KeepStart; math.Abs(keep-duration/9) > 1e-6 {
+			t.Errorf("%d. parça %.1f sn, beklenen %.1f", i, keep, duration/9)
+		}
+	}
+}
+
+func TestPlanChunksPrefersSilenceNearTarget(t *testing.T) {
+	silences := []Silence{
+		{Start: 950, End: 960},   // pencerenin (900-1798) içinde ama hedefe uzak
+		{Start: 1490, End: 1510}, // hedefe (1500) en yakın
+		{Start: 1900, End: 1950}, // pencerenin dışında
+	}
+	chunks := PlanChunks(3000, silences, 1800, 1)
+	checkChunks(t, chunks, 3000, 1800, 1)
+
+	if len(chunks) != 2 {
+		t.Fatalf("parça sayısı = %d, beklenen 2", len(chunks))
+	}
+	if chunks[0].KeepEnd != 1500 {
+		t.Errorf("bölme noktası %.1f, beklenen 1500 (sessizliğin ortası)", chunks[0].KeepEnd)
+	}
+}
+
+func TestParseSilenceDetect(t *testing.T) {
+	output := `[silencedetect @ 0x1] silence_start: -0.01
+[silencedetect @ 0x1] silence_end: 1.5 | silence_duration: 1.51
+size=N/A time=00:00:10.00
+[silencedetect @ 0x1] silence_start: 4.25
+[silencedetect @ 0x1] silence_end: 5 | silence_duration: 0.75
+[silencedetect @ 0x1] silence_start: 9.5
+`
+	silences := parseSilenceDetect(output, 10)
+	want := []Silence{{0, 1.5}, {4.25, 5}, {9.5, 10}}
+	if len(silences) != len(want) {
+		t.Fatalf("sessizlikler = %v, beklenen %v", silences, want)
+	}
+	for i := range want {
+		if silences[i] != want[i] {
+			t.Errorf("%d. sessizlik = %v, beklenen %v", i, silences[i], want[i])
+		}
+	}
+}
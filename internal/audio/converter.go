@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"spt2/pkg/models"
@@ -23,21 +24,69 @@ func generateOutputPath(inputPath string, outputDir string) string {
 }
 
 //ses dosyasını flac formatına dönüştürme
-func ConvertToFLAC(metadata *models.AudioMetadata, outputDir string) error{
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+//
+//örnekleme hızı cfg.TargetSampleRate'ten alınır; cfg.MultiChannel açıksa
+//kanallar korunur (multi_channel olmadan convert_to_mono false config'te
+//reddedilir, bkz. validateAppConfig). Örnekler 16 bit'e indirilir.
+//Ön işleme açıksa (trim_silence, preprocess_*) ses BuildFilterGraph'in
+//zincirinden geçirilir ve uygulanan zincir metadata.FilterGraph'e yazılır.
+//Dönüşümden sonra FLAC header'ı okunup istenen değerlerle karşılaştırılır.
+//outputPath boşsa FLAC output_dir/<dosya_adı>.flac'a yazılır; aynı adlı
+//kaynakların birbirinin FLAC'ini ezmemesi için pipeline iş ID'li yol verir.
+func ConvertToFLAC(metadata *models.AudioMetadata, cfg *models.AppConfig, outputPath string) error{
+	if err := os.MkdirAll(cfg.OutputDir, 0755); err != nil {
 		return fmt.Errorf("output dizini oluşturulamadı: %w", err)
 	}
 
-	outputPath := generateOutputPath(metadata.FilePath, outputDir)
+	if outputPath == "" {
+		outputPath = generateOutputPath(metadata.FilePath, cfg.OutputDir)
+	}
+
+	//seçilen ses izi alınır, video ve kapak resmi atılır
+	args := []string{"-i", metadata.FilePath,
+		"-map", fmt.Sprintf("0:a:%d", metadata.SelectedTrack), "-vn",
+		"-ar", strconv.Itoa(cfg.TargetSampleRate), "-sample_fmt", "s16"}
+	mono := cfg.ConvertToMono && !cfg.MultiChannel
+	if mono {
+		args = append(args, "-ac", "1")
+	}
+	filterGraph, err := BuildFilterGraph(metadata, cfg)
+	if err != nil {
+		metadata.ConversionStatus = "failure"
+		return err
+	}
+	if filterGraph != "" {
+		args = append(args, "-af", filterGraph)
+	}
+	args = append(args, "-y", outputPath)
 
-	cmd := exec.Command("ffmpeg", "-i", metadata.FilePath, "-ar", "16000", "-ac", "1", "-y", outputPath)
+	cmd := exec.Command("ffmpeg", args...)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		metadata.ConversionStatus = "failure"
 		return fmt.Errorf("FFmpeg hatası: %w\nÇıktı: %s", err, string(output))
 	}
 
+	info, err := ReadFLACInfo(outputPath)
+	if err != nil {
+		metadata.ConversionStatus = "failure"
+		return err
+	}
+	if info.SampleRate != cfg.TargetSampleRate {
+		metadata.ConversionStatus = "failure"
+		return fmt.Errorf("FLAC örnekleme hızı beklenenden farklı: %d Hz (beklenen: %d Hz)", info.SampleRate, cfg.TargetSampleRate)
+	}
+	if mono && info.Channels != 1 {
+		metadata.ConversionStatus = "failure"
+		return fmt.Errorf("FLAC mono değil: %d kanal", info.Channels)
+	}
+
 	metadata.ConvertedPath = outputPath
+	metadata.ConvertedSampleRate = info.SampleRate
+	metadata.ConvertedChannels = info.Channels
+	metadata.ConvertedDuration = info.Duration()
+	metadata.FilterGraph = filterGraph
 	metadata.ConversionStatus = "completed"
 
 	return nil
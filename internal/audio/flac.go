@@ -0,0 +1,63 @@
+package audio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// FLACInfo - FLAC dosyasının STREAMINFO bloğundaki ses özellikleri
+type FLACInfo struct {
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+	TotalSamples  int64
+}
+
+// Duration - toplam örnek sayısından süre (saniye); header'da yoksa 0
+func (info *FLACInfo) Duration() float64 {
+	if info.SampleRate == 0 {
+		return 0
+	}
+	return float64(info.TotalSamples) / float64(info.SampleRate)
+}
+
+// ReadFLACInfo - FLAC header'ını (fLaC + STREAMINFO) okuma
+//
+// STREAMINFO her zaman ilk metadata bloğudur:
+//
+//	"fLaC" | blok başlığı (1 bayt tip + 3 bayt uzunluk) | STREAMINFO (34 bayt)
+//
+// STREAMINFO içinde 10. bayttan itibaren: 20 bit örnekleme hızı, 3 bit
+// kanal sayısı-1, 5 bit örnek başına bit-1, 36 bit toplam örnek sayısı.
+func ReadFLACInfo(filePath string) (*FLACInfo, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("FLAC dosyası açılamadı: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, 42)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return nil, fmt.Errorf("FLAC header okunamadı: %w", err)
+	}
+
+	if !bytes.Equal(header[0:4], []byte("fLaC")) {
+		return nil, fmt.Errorf("geçerli bir FLAC dosyası değil: %s", filePath)
+	}
+	if header[4]&0x7F != 0 {
+		return nil, fmt.Errorf("FLAC dosyasında STREAMINFO bloğu yok: %s", filePath)
+	}
+
+	streamInfo := header[8:]
+	info := &FLACInfo{
+		SampleRate:    int(streamInfo[10])<<12 | int(streamInfo[11])<<4 | int(streamInfo[12])>>4,
+		Channels:      int((streamInfo[12]>>1)&0x07) + 1,
+		BitsPerSample: (int(streamInfo[12]&0x01)<<4 | int(streamInfo[13])>>4) + 1,
+		TotalSamples: int64(streamInfo[13]&0x0F)<<32 | int64(streamInfo[14])<<24 |
+			int64(streamInfo[15])<<16 | int64(streamInfo[16])<<8 | int64(streamInfo[17]),
+	}
+
+	return info, nil
+}
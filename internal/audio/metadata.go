@@ -3,8 +3,10 @@
 package audio
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -12,8 +14,21 @@ import (
 	"spt2/pkg/models"
 )
 
-//dosya uzantısından ses formatını tespit edecek
-func detectFormat(filePath string) string {
+//aynı container ailesinden sayılan uzantılar (.m4a içeriği mp4 olabilir vb.)
+var formatFamilies = map[string]string{
+	"m4a" : "mp4",
+	"m4b" : "mp4",
+	"mp4" : "mp4",
+	"ogg" : "ogg",
+	"oga" : "ogg",
+	"opus": "ogg",
+	"mov" : "mp4",
+	"mkv" : "matroska",
+	"webm": "matroska",
+}
+
+//dosya uzantısından formatı okuma
+func extensionFormat(filePath string) string {
 	ext := strings.ToLower(filepath.Ext(filePath))
 
 	if len(ext) > 0 && ext[0] == '.' {
@@ -23,6 +38,42 @@ func detectFormat(filePath string) string {
 	return ext
 }
 
+func sameFormatFamily(ext, container string) bool {
+	if ext == container {
+		return true
+	}
+	family, ok := formatFamilies[ext]
+	return ok && family == formatFamilies[container]
+}
+
+//dosya içeriğinden (magic bytes) ses formatını tespit edecek
+//içerik tanınamazsa uzantıya düşülür; uzantı ile içerik uyuşmazsa uyarı döner
+func detectFormat(filePath string) (container string, codec string, warning string, err error) {
+	ext := extensionFormat(filePath)
+
+	container, codec, err = sniffFile(filePath)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if container == "" {
+		if ext == "" {
+			return "", "", "dosya içeriği tanınamadı ve dosya uzantısı yok", nil
+		}
+		return ext, "", fmt.Sprintf("dosya içeriği tanınamadı, uzantıya (.%s) güveniliyor", ext), nil
+	}
+
+	if ext != "" && !sameFormatFamily(ext, container) {
+		detected := container
+		if codec != "" && codec != container {
+			detected = fmt.Sprintf("%s (%s)", container, codec)
+		}
+		warning = fmt.Sprintf("dosya uzantısı .%s ama içerik %s; içerikteki format kullanılacak", ext, detected)
+	}
+
+	return container, codec, warning, nil
+}
+
 //ses dosyasından metadata bilgilerini çıkaracak fonk
 func ExtractMetadata(filePath string) (*models.AudioMetadata, error) {
 	fileInfo, err := os.Stat(filePath)
@@ -30,22 +81,44 @@ func ExtractMetadata(filePath string) (*models.AudioMetadata, error) {
 		return nil, fmt.Errorf("dosya bulunamadı: %w", err)
 	}
 
+	container, codec, warning, err := detectFormat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("dosya formatı tespit edilemedi: %w", err)
+	}
+
 	//metadata structı
 	metadata := &models.AudioMetadata{
 		FilePath:		  filePath,
-		OriginalFormat:   detectFormat(filePath),
+		OriginalFormat:   container,
+		Container:		  container,
+		Codec:			  codec,
+		FormatWarning:	  warning,
 		FileSize:		  fileInfo.Size(),
 		CreatedAt:		  time.Now(),
 		IsValid:		  true,
 		ConversionStatus: "pending",
+	}
 
-		Duration:		  0.0,
-		SampleRate:		  16000,
-		Channels:		  1,
-		BitDepth:		  16,
-		Codec:			  "unknown",
+	//ses özellikleri ffprobe ile okunur; ffprobe kurulu değilse WAV ve FLAC header'dan
+	probe, err := runFFprobe(filePath)
+	if errors.Is(err, exec.ErrNotFound) {
+		probe, err = nativeProbe(filePath, container)
+		if err != nil {
+			return nil, fmt.Errorf("ffprobe bulunamadı: %w", err)
+		}
+		metadata.FormatWarning = joinWarnings(metadata.FormatWarning, "ffprobe bulunamadı; ses özellikleri dosya header'ından okundu")
 	}
+	if err != nil {
+		return nil, fmt.Errorf("ses dosyası incelenemedi: %w", err)
+	}
+	applyProbe(metadata, probe)
+
 	return metadata, nil
 }
 
-
+func joinWarnings(warning string, extra string) string {
+	if warning == "" {
+		return extra
+	}
+	return warning + "; " + extra
+}
@@ -0,0 +1,175 @@
+package audio
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+// seconds saniyelik sessiz 16 bit PCM WAV (fmt ile data arasında LIST chunk'ı)
+func writeTestWAV(t *testing.T, path string, sampleRate int, channels int, seconds float64) {
+	t.Helper()
+	dataSize := int(seconds*float64(sampleRate)) * channels * 2
+	list := []byte("INFOISFT\x05\x00\x00\x00spt2\x00\x00") // tek boyutlu alt chunk + dolgu
+
+	var buf []byte
+	buf = append(buf, "RIFF"...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(4+8+16+8+len(list)+8+dataSize))
+	buf = append(buf, "WAVEfmt "...)
+	buf = binary.LittleEndian.AppendUint32(buf, 16)
+	buf = binary.LittleEndian.AppendUint16(buf, wavFormatPCM)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(channels))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(sampleRate))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(sampleRate*channels*2))
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(channels*2))
+	buf = binary.LittleEndian.AppendUint16(buf, 16)
+	buf = append(buf, "LIST"...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(list)))
+	buf = append(buf, list...)
+	buf = append(buf, "data"...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(dataSize))
+	buf = append(buf, make([]byte, dataSize)...)
+
+	if err := os.WriteFile(path, buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// yalnızca fLaC + STREAMINFO'dan oluşan FLAC header'ı
+func writeTestFLAC(t *testing.T, path string, sampleRate int, channels int, bits int, samples int64) {
+	t.Helper()
+	streamInfo := make([]byte, 34)
+	streamInfo[10] = byte(sampleRate >> 12)
+	streamInfo[11] = byte(sampleRate >> 4)
+	streamInfo[12] = byte(sampleRate<<4) | byte((channels-1)<<1) | byte((bits-1)>>4)
+	streamInfo[13] = byte((bits-1)<<4) | byte(samples>>32&0x0F)
+	binary.BigEndian.PutUint32(streamInfo[14:18], uint32(samples))
+
+	buf := append([]byte("fLaC"), 0x80, 0, 0, 34)
+	buf = append(buf, streamInfo...)
+	if err := os.WriteFile(path, buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadWAVInfo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kayit.wav")
+	writeTestWAV(t, path, 16000, 2, 1.5)
+
+	info, err := ReadWAVInfo(path)
+	if err != nil {
+		t.Fatalf("ReadWAVInfo: %v", err)
+	}
+	if info.SampleRate != 16000 || info.Channels != 2 || info.BitsPerSample != 16 || info.Codec() != "pcm_s16le" {
+		t.Errorf("WAV bilgisi = %+v (%s)", info, info.Codec())
+	}
+	if info.Duration() != 1.5 {
+		t.Errorf("süre = %.3f, beklenen 1.5", info.Duration())
+	}
+}
+
+// ffprobe PATH'te yoksa WAV ve FLAC header'dan okunmalı
+func TestExtractMetadataWithoutFFprobe(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	dir := t.TempDir()
+
+	wavPath := filepath.Join(dir, "ders.wav")
+	writeTestWAV(t, wavPath, 44100, 1, 2)
+	metadata, err := ExtractMetadata(wavPath)
+	if err != nil {
+		t.Fatalf("WAV: %v", err)
+	}
+	if metadata.Duration != 2 || metadata.SampleRate != 44100 || metadata.Channels != 1 || metadata.BitDepth != 16 {
+		t.Errorf("WAV metadata: %.2f sn, %d Hz, %d kanal, %d bit", metadata.Duration, metadata.SampleRate, metadata.Channels, metadata.BitDepth)
+	}
+	if !strings.Contains(metadata.FormatWarning, "ffprobe bulunamadı") {
+		t.Errorf("uyarı = %q", metadata.FormatWarning)
+	}
+
+	flacPath := filepath.Join(dir, "ders.flac")
+	writeTestFLAC(t, flacPath, 48000, 2, 24, 48000*3)
+	metadata, err = ExtractMetadata(flacPath)
+	if err != nil {
+		t.Fatalf("FLAC: %v", err)
+	}
+	if metadata.Duration != 3 || metadata.SampleRate != 48000 || metadata.Channels != 2 || metadata.BitDepth != 24 {
+		t.Errorf("FLAC metadata: %.2f sn, %d Hz, %d kanal, %d bit", metadata.Duration, metadata.SampleRate, metadata.Channels, metadata.BitDepth)
+	}
+
+	mp3Path := filepath.Join(dir, "ders.mp3")
+	if err := os.WriteFile(mp3Path, []byte{0xFF, 0xFB, 0x90, 0x64, 0, 0, 0, 0}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ExtractMetadata(mp3Path); err == nil || !strings.Contains(err.Error(), "ffprobe bulunamadı") {
+		t.Errorf("MP3 için ffprobe hatası bekleniyordu: %v", err)
+	}
+}
+
+func TestStreamBitDepth(t *testing.T) {
+	tests := []struct {
+		stream ffprobeStream
+		want   int
+	}{
+		{ffprobeStream{CodecName: "pcm_s24le", BitsPerSample: 24, SampleFmt: "s32"}, 24},
+		{ffprobeStream{CodecName: "flac", BitsPerRawSample: "16", SampleFmt: "s16"}, 16},
+		{ffprobeStream{CodecName: "alac", SampleFmt: "s32p"}, 32},
+		{ffprobeStream{CodecName: "mp3", SampleFmt: "fltp"}, 0},
+		{ffprobeStream{CodecName: "aac", SampleFmt: "fltp"}, 0},
+		{ffprobeStream{CodecName: "opus", SampleFmt: "flt"}, 0},
+	}
+	for _, test := range tests {
+		if got := streamBitDepth(&test.stream); got != test.want {
+			t.Errorf("%s: bit derinliği %d, beklenen %d", test.stream.CodecName, got, test.want)
+		}
+	}
+}
+
+// video ve data akışları önce gelse veya araya girse de izler 0:a:N sırasıyla
+func TestApplyProbeOrdersTracksByAudioOrdinal(t *testing.T) {
+	probe := &ffprobeOutput{
+		Streams: []ffprobeStream{
+			{Index: 0, CodecType: "video", CodecName: "h264", AvgFrameRate: "25/1", Duration: "600"},
+			{Index: 1, CodecType: "data", CodecName: "bin_data"},
+			{Index: 3, CodecType: "audio", CodecName: "ac3", SampleRate: "48000", Channels: 6, Duration: "598",
+				Tags: map[string]string{"language": "tur"}, Disposition: map[string]int{"default": 1}},
+			{Index: 2, CodecType: "audio", CodecName: "aac", SampleRate: "44100", Channels: 2, Duration: "599",
+				Tags: map[string]string{"language": "eng"}},
+			{Index: 4, CodecType: "subtitle", CodecName: "subrip"},
+		},
+	}
+	metadata := &models.AudioMetadata{}
+	applyProbe(metadata, probe)
+
+	if len(metadata.AudioTracks) != 2 || metadata.AudioStreams != 2 {
+		t.Fatalf("%d ses izi, beklenen 2", len(metadata.AudioTracks))
+	}
+	eng, tur := metadata.AudioTracks[0], metadata.AudioTracks[1]
+	if eng.Index != 0 || eng.StreamIndex != 2 || eng.Language != "eng" {
+		t.Errorf("0:a:0 = %+v, beklenen stream 2 (eng)", eng)
+	}
+	if tur.Index != 1 || tur.StreamIndex != 3 || tur.Language != "tur" {
+		t.Errorf("0:a:1 = %+v, beklenen stream 3 (tur)", tur)
+	}
+
+	// varsayılan iz seçilmeli; format süresi yoksa o izin süresi kullanılmalı
+	if metadata.SelectedTrack != 1 || metadata.Codec != "ac3" || metadata.Channels != 6 || metadata.SampleRate != 48000 {
+		t.Errorf("seçilen iz %d: %s, %d kanal, %d Hz", metadata.SelectedTrack, metadata.Codec, metadata.Channels, metadata.SampleRate)
+	}
+	if metadata.Duration != 598 {
+		t.Errorf("süre = %.0f, beklenen 598 (seçilen izin süresi)", metadata.Duration)
+	}
+	if !metadata.HasVideo || metadata.FrameRate != 25 {
+		t.Errorf("video: %t, %.2f fps", metadata.HasVideo, metadata.FrameRate)
+	}
+
+	if err := SelectAudioTrack(metadata, -1, "en-US"); err != nil {
+		t.Fatal(err)
+	}
+	if metadata.SelectedTrack != 0 || metadata.Codec != "aac" {
+		t.Errorf("en-US için seçilen iz %d (%s), beklenen 0 (aac)", metadata.SelectedTrack, metadata.Codec)
+	}
+}
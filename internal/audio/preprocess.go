@@ -0,0 +1,140 @@
+package audio
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"spt2/pkg/models"
+)
+
+// loudnorm'un tepe ve ses aralığı hedefleri (ffmpeg varsayılanları)
+const (
+	loudnormTruePeak = -2.0
+	loudnormRange    = 7.0
+)
+
+// ön işleme zincirinin loudnorm'dan önceki filtreleri
+//
+// SIRA: sessizlik kırpma (atrim) → high-pass → gürültü azaltma. Kırpma önce
+// gelir ki sonraki filtreler ve loudnorm ölçümü yalnızca konuşma aralığını görsün.
+func preprocessFilters(metadata *models.AudioMetadata, cfg *models.AppConfig) []string {
+	var filters []string
+
+	if metadata.TrimEnd > 0 {
+		filters = append(filters,
+			fmt.Sprintf("atrim=start=%s:end=%s", formatSeconds(metadata.TrimStart), formatSeconds(metadata.TrimEnd)),
+			"asetpts=PTS-STARTPTS")
+	}
+
+	if cfg.PreprocessHighpass > 0 {
+		filters = append(filters, "highpass=f="+strconv.FormatFloat(cfg.PreprocessHighpass, 'f', -1, 64))
+	}
+
+	switch cfg.PreprocessDenoise {
+	case "afftdn":
+		filters = append(filters, "afftdn=nr="+strconv.FormatFloat(cfg.PreprocessDenoiseStrength, 'f', -1, 64))
+	case "arnndn":
+		filters = append(filters, "arnndn=m="+quoteFilterValue(cfg.PreprocessDenoiseModel))
+	}
+
+	return filters
+}
+
+// BuildFilterGraph - ConvertToFLAC'in uygulayacağı ffmpeg filtre zinciri (-af)
+//
+// KURALLAR (config: trim_silence, preprocess_*):
+// - trim_silence: VAD'ın bulduğu ilk ve son konuşma dışı kırpılır (atrim)
+// - preprocess_highpass: bu frekansın (Hz) altı kesilir (klima/havalandırma uğultusu)
+// - preprocess_denoise: "afftdn" (FFT, preprocess_denoise_strength dB) veya "arnndn" (preprocess_denoise_model)
+// - preprocess_loudnorm: EBU R128 loudness normalizasyonu (preprocess_loudnorm_target LUFS)
+//
+// Loudnorm iki geçişlidir: önce önceki filtrelerden geçen ses ölçülür, sonra
+// ölçülen değerlerle doğrusal (linear) normalizasyon uygulanır. Ölçüm değerleri
+// zincire yazıldığından metadata'daki FilterGraph aynı sonucu yeniden üretir.
+// Zincir boşsa "" döner.
+func BuildFilterGraph(metadata *models.AudioMetadata, cfg *models.AppConfig) (string, error) {
+	filters := preprocessFilters(metadata, cfg)
+
+	if cfg.PreprocessLoudnorm {
+		loudnorm, err := measureLoudness(metadata, filters, cfg)
+		if err != nil {
+			return "", err
+		}
+		if loudnorm != "" {
+			filters = append(filters, loudnorm)
+		}
+	}
+
+	return strings.Join(filters, ","), nil
+}
+
+// loudnorm ölçüm geçişinin (print_format=json) kullanılan alanları
+type loudnormMeasurement struct {
+	InputI       string `json:"input_i"`
+	InputTP      string `json:"input_tp"`
+	InputLRA     string `json:"input_lra"`
+	InputThresh  string `json:"input_thresh"`
+	TargetOffset string `json:"target_offset"`
+}
+
+// ölçüm geçişi; ses tamamen sessizse (-inf) normalizasyon atlanır ve "" döner
+func measureLoudness(metadata *models.AudioMetadata, filters []string, cfg *models.AppConfig) (string, error) {
+	target := loudnormTarget(cfg)
+	measure := append(append([]string(nil), filters...), target+":print_format=json")
+
+	args := []string{"-hide_banner", "-nostats", "-i", metadata.FilePath,
+		"-map", fmt.Sprintf("0:a:%d", metadata.SelectedTrack), "-vn"}
+	if cfg.ConvertToMono && !cfg.MultiChannel {
+		args = append(args, "-ac", "1")
+	}
+	args = append(args, "-af", strings.Join(measure, ","), "-f", "null", "-")
+
+	output, err := exec.Command("ffmpeg", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("FFmpeg loudness ölçüm hatası: %w\nÇıktı: %s", err, string(output))
+	}
+
+	measurement, err := parseLoudnorm(string(output))
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(measurement.InputI, "inf") {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s:measured_I=%s:measured_TP=%s:measured_LRA=%s:measured_thresh=%s:offset=%s:linear=true",
+		target, measurement.InputI, measurement.InputTP, measurement.InputLRA, measurement.InputThresh, measurement.TargetOffset), nil
+}
+
+func loudnormTarget(cfg *models.AppConfig) string {
+	return fmt.Sprintf("loudnorm=I=%s:TP=%s:LRA=%s",
+		strconv.FormatFloat(cfg.PreprocessLoudnormTarget, 'f', -1, 64),
+		strconv.FormatFloat(loudnormTruePeak, 'f', -1, 64),
+		strconv.FormatFloat(loudnormRange, 'f', -1, 64))
+}
+
+// ffmpeg çıktısının sonundaki loudnorm JSON bloğu
+func parseLoudnorm(output string) (*loudnormMeasurement, error) {
+	start := strings.LastIndex(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("loudnorm ölçümü FFmpeg çıktısında bulunamadı")
+	}
+
+	var measurement loudnormMeasurement
+	if err := json.Unmarshal([]byte(output[start:end+1]), &measurement); err != nil {
+		return nil, fmt.Errorf("loudnorm ölçümü parse edilemedi: %w", err)
+	}
+	if measurement.InputI == "" {
+		return nil, fmt.Errorf("loudnorm ölçümü eksik: %s", output[start:end+1])
+	}
+	return &measurement, nil
+}
+
+// filtre parametresi olarak dosya yolu: tek tırnak içinde, ' kaçışlı
+func quoteFilterValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
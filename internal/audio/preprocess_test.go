@@ -0,0 +1,119 @@
+package audio
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+func TestPreprocessFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata models.AudioMetadata
+		cfg      models.AppConfig
+		want     string
+	}{
+		{"kapalı", models.AudioMetadata{}, models.AppConfig{PreprocessDenoise: "none"}, ""},
+		{"kırpma", models.AudioMetadata{TrimStart: 1.5, TrimEnd: 60.25}, models.AppConfig{},
+			"atrim=start=1.500:end=60.250,asetpts=PTS-STARTPTS"},
+		{"high-pass", models.AudioMetadata{}, models.AppConfig{PreprocessHighpass: 80}, "highpass=f=80"},
+		{"afftdn", models.AudioMetadata{}, models.AppConfig{PreprocessDenoise: "afftdn", PreprocessDenoiseStrength: 12}, "afftdn=nr=12"},
+		{"arnndn", models.AudioMetadata{}, models.AppConfig{PreprocessDenoise: "arnndn", PreprocessDenoiseModel: "/modeller/it's.rnnn"},
+			`arnndn=m='/modeller/it'\''s.rnnn'`},
+		{"sıra: kırpma, high-pass, gürültü", models.AudioMetadata{TrimStart: 2, TrimEnd: 10},
+			models.AppConfig{PreprocessHighpass: 100, PreprocessDenoise: "afftdn", PreprocessDenoiseStrength: 20},
+			"atrim=start=2.000:end=10.000,asetpts=PTS-STARTPTS,highpass=f=100,afftdn=nr=20"},
+	}
+	for _, test := range tests {
+		got, err := BuildFilterGraph(&test.metadata, &test.cfg)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if got != test.want {
+			t.Errorf("%s:\n%s\nbeklenen\n%s", test.name, got, test.want)
+		}
+	}
+}
+
+// loudnorm ölçüm çıktısını yazan ve argümanlarını FAKE_FFMPEG_ARGS'a kaydeden sahte ffmpeg
+const fakeLoudnormScript = `#!/bin/sh
+echo "$*" > "$FAKE_FFMPEG_ARGS"
+printf '%s\n' "$FAKE_LOUDNORM_OUTPUT" >&2
+`
+
+func TestBuildFilterGraphLoudnorm(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ffmpeg"), []byte(fakeLoudnormScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	argsPath := filepath.Join(dir, "args")
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_FFMPEG_ARGS", argsPath)
+
+	metadata := &models.AudioMetadata{FilePath: "ders.wav", SelectedTrack: 1}
+	cfg := &models.AppConfig{ConvertToMono: true, PreprocessHighpass: 80, PreprocessLoudnorm: true, PreprocessLoudnormTarget: -16}
+
+	t.Setenv("FAKE_LOUDNORM_OUTPUT", `[Parsed_loudnorm_1 @ 0x1]
+{
+	"input_i" : "-27.61",
+	"input_tp" : "-4.47",
+	"input_lra" : "18.06",
+	"input_thresh" : "-39.20",
+	"target_offset" : "0.58"
+}`)
+	graph, err := BuildFilterGraph(metadata, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "highpass=f=80,loudnorm=I=-16:TP=-2:LRA=7:measured_I=-27.61:measured_TP=-4.47:measured_LRA=18.06:measured_thresh=-39.20:offset=0.58:linear=true"
+	if graph != want {
+		t.Errorf("zincir:\n%s\nbeklenen\n%s", graph, want)
+	}
+
+	// ölçüm geçişi seçilen izi mono olarak, önceki filtrelerden geçirip ölçmeli
+	args, err := os.ReadFile(argsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"-map 0:a:1", "-ac 1", "-af highpass=f=80,loudnorm=I=-16:TP=-2:LRA=7:print_format=json"} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("ölçüm argümanlarında %q yok: %s", want, args)
+		}
+	}
+
+	// tamamen sessiz ses normalize edilmez
+	t.Setenv("FAKE_LOUDNORM_OUTPUT", `{"input_i": "-inf", "input_tp": "-inf", "input_lra": "0.00", "input_thresh": "-70.00", "target_offset": "inf"}`)
+	if graph, err := BuildFilterGraph(metadata, cfg); err != nil || graph != "highpass=f=80" {
+		t.Errorf("sessiz ses: %q (%v)", graph, err)
+	}
+
+	t.Setenv("FAKE_LOUDNORM_OUTPUT", "loudnorm çıktısı yok")
+	if _, err := BuildFilterGraph(metadata, cfg); err == nil {
+		t.Error("ölçüm bulunamazsa hata bekleniyordu")
+	}
+}
+
+func TestParseLoudnorm(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		wantErr bool
+	}{
+		{"son blok", `{"input_i": "-1"} ... {"input_i": "-23.5", "target_offset": "0.1"}`, false},
+		{"blok yok", "Error", true},
+		{"bozuk", "{input_i}", true},
+		{"input_i eksik", `{"input_tp": "-1"}`, true},
+	}
+	for _, test := range tests {
+		measurement, err := parseLoudnorm(test.output)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: hata %v", test.name, err)
+		}
+		if err == nil && measurement.InputI != "-23.5" {
+			t.Errorf("%s: input_i = %s", test.name, measurement.InputI)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package audio
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"spt2/pkg/models"
+)
+
+// ffprobe -show_format -show_streams JSON çıktısının kullanılan kısmı
+type ffprobeOutput struct {
+	Streams []ffprobeStream `json:"streams"`
+	Format  ffprobeFormat   `json:"format"`
+}
+
+type ffprobeStream struct {
+	Index            int               `json:"index"`
+	CodecType        string            `json:"codec_type"` // "audio", "video", ...
+	CodecName        string            `json:"codec_name"`
+	SampleRate       string            `json:"sample_rate"`
+	Channels         int               `json:"channels"`
+	SampleFmt        string            `json:"sample_fmt"`
+	BitsPerSample    int               `json:"bits_per_sample"`
+	BitsPerRawSample string            `json:"bits_per_raw_sample"`
+	Duration         string            `json:"duration"`
+	BitRate          string            `json:"bit_rate"`
+	AvgFrameRate     string            `json:"avg_frame_rate"` // "30000/1001"
+	RFrameRate       string            `json:"r_frame_rate"`
+	Disposition      map[string]int    `json:"disposition"` // "default", "attached_pic", ...
+	Tags             map[string]string `json:"tags"`
+}
+
+type ffprobeFormat struct {
+	FormatName string `json:"format_name"`
+	Duration   string `json:"duration"`
+	BitRate    string `json:"bit_rate"`
+}
+
+// ffprobe ile dosyanın format ve stream bilgilerini okuma
+func runFFprobe(filePath string) (*ffprobeOutput, error) {
+	cmd := exec.Command("ffprobe", "-v", "error", "-print_format", "json", "-show_format", "-show_streams", filePath)
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("FFprobe hatası: %w\nÇıktı: %s", err, string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("FFprobe çalıştırılamadı: %w", err)
+	}
+
+	var probe ffprobeOutput
+	if err := json.Unmarshal(output, &probe); err != nil {
+		return nil, fmt.Errorf("FFprobe çıktısı parse edilemedi: %w", err)
+	}
+
+	return &probe, nil
+}
+
+// nativeProbe - ffprobe yokken WAV ve FLAC header'ından tek ses akışlı probe sonucu
+//
+// Diğer formatların süresi ve ses özellikleri ffprobe olmadan okunamaz.
+func nativeProbe(filePath string, container string) (*ffprobeOutput, error) {
+	var stream ffprobeStream
+	var duration float64
+
+	switch container {
+	case "wav":
+		info, err := ReadWAVInfo(filePath)
+		if err != nil {
+			return nil, err
+		}
+		stream = ffprobeStream{CodecName: info.Codec(), SampleRate: strconv.Itoa(info.SampleRate),
+			Channels: info.Channels, BitsPerSample: info.BitsPerSample}
+		if info.ByteRate > 0 {
+			stream.BitRate = strconv.FormatInt(info.ByteRate*8, 10)
+		}
+		duration = info.Duration()
+	case "flac":
+		info, err := ReadFLACInfo(filePath)
+		if err != nil {
+			return nil, err
+		}
+		stream = ffprobeStream{CodecName: "flac", SampleRate: strconv.Itoa(info.SampleRate),
+			Channels: info.Channels, BitsPerSample: info.BitsPerSample}
+		duration = info.Duration()
+	default:
+		return nil, fmt.Errorf("ffprobe olmadan yalnızca WAV ve FLAC dosyaları okunabilir (%s)", container)
+	}
+
+	stream.CodecType = "audio"
+	stream.Disposition = map[string]int{"default": 1}
+	return &ffprobeOutput{
+		Streams: []ffprobeStream{stream},
+		Format: ffprobeFormat{
+			FormatName: container,
+			Duration:   strconv.FormatFloat(duration, 'f', -1, 64),
+		},
+	}, nil
+}
+
+// probe sonucunu metadata'ya işleme
+//
+// Her ses akışı AudioTracks'e eklenir (0 tane ise ValidateMetadata dosyayı
+// reddeder). Ses özellikleri varsayılan işaretli ize, yoksa ilk ize göre
+// doldurulur; farklı bir iz SelectAudioTrack ile seçilebilir.
+// Kapak resmi (attached_pic) video sayılmaz.
+//
+// İzin sırası (Index) ses akışları arasındaki sıradır ve ffmpeg'in 0:a:N
+// seçicisiyle aynıdır; video/data akışları önce gelse veya ses akışlarının
+// arasına girse de stream numarasından (StreamIndex) bağımsızdır.
+func applyProbe(metadata *models.AudioMetadata, probe *ffprobeOutput) {
+	var videoStream *ffprobeStream
+	var audioStreams []*ffprobeStream
+	for i := range probe.Streams {
+		stream := &probe.Streams[i]
+		switch stream.CodecType {
+		case "audio":
+			audioStreams = append(audioStreams, stream)
+		case "video":
+			if videoStream == nil && stream.Disposition["attached_pic"] == 0 {
+				videoStream = stream
+			}
+		}
+	}
+	// ffmpeg akışları stream numarasına göre sayar
+	sort.SliceStable(audioStreams, func(a, b int) bool { return audioStreams[a].Index < audioStreams[b].Index })
+
+	for ordinal, stream := range audioStreams {
+		metadata.AudioTracks = append(metadata.AudioTracks, models.AudioTrack{
+			Index:       ordinal,
+			StreamIndex: stream.Index,
+			Codec:       stream.CodecName,
+			Language:    stream.Tags["language"],
+			Title:       stream.Tags["title"],
+			SampleRate:  int(parseInt(stream.SampleRate)),
+			Channels:    stream.Channels,
+			BitDepth:    streamBitDepth(stream),
+			BitRate:     parseInt(stream.BitRate),
+			Default:     stream.Disposition["default"] == 1,
+		})
+	}
+	metadata.AudioStreams = len(metadata.AudioTracks)
+
+	metadata.Duration = parseFloat(probe.Format.Duration)
+	metadata.BitRate = parseInt(probe.Format.BitRate)
+
+	if videoStream != nil {
+		metadata.HasVideo = true
+		metadata.VideoCodec = videoStream.CodecName
+		metadata.FrameRate = parseFrameRate(videoStream.AvgFrameRate)
+		if metadata.FrameRate <= 0 {
+			metadata.FrameRate = parseFrameRate(videoStream.RFrameRate)
+		}
+		metadata.VideoDuration = parseFloat(videoStream.Duration)
+		if metadata.VideoDuration <= 0 {
+			metadata.VideoDuration = metadata.Duration // mkv/webm stream süresi vermez
+		}
+	}
+
+	if len(metadata.AudioTracks) == 0 {
+		return
+	}
+
+	selected := 0
+	for _, track := range metadata.AudioTracks {
+		if track.Default {
+			selected = track.Index
+			break
+		}
+	}
+	applyTrack(metadata, selected)
+
+	if metadata.Duration <= 0 {
+		metadata.Duration = parseFloat(audioStreams[selected].Duration)
+	}
+}
+
+// seçilen ses izinin özelliklerini metadata'ya yazma
+func applyTrack(metadata *models.AudioMetadata, index int) {
+	track := metadata.AudioTracks[index]
+
+	metadata.SelectedTrack = index
+	metadata.SampleRate = track.SampleRate
+	metadata.Channels = track.Channels
+	metadata.BitDepth = track.BitDepth
+	if track.BitRate > 0 {
+		metadata.BitRate = track.BitRate
+	}
+	if track.Codec != "" {
+		metadata.Codec = track.Codec
+	}
+}
+
+// örnek bilgisi taşıyan kayıpsız codec'ler (pcm_* ayrıca)
+var losslessCodecs = map[string]bool{
+	"flac": true, "alac": true, "wavpack": true, "ape": true, "tta": true, "mlp": true, "truehd": true,
+}
+
+// örnek başına bit sayısı
+//
+// Kayıpsız codec'ler (flac, pcm) bits_per_raw_sample / bits_per_sample verir,
+// vermezlerse decoder'ın sample_fmt'si kullanılır. Kayıplı codec'lerin (mp3,
+// aac, opus) bit derinliği yoktur; decoder'ın fltp çıktısı 32 sayılmaz, 0 döner.
+func streamBitDepth(stream *ffprobeStream) int {
+	if !strings.HasPrefix(stream.CodecName, "pcm_") && !losslessCodecs[stream.CodecName] {
+		return 0
+	}
+	if bits := int(parseInt(stream.BitsPerRawSample)); bits > 0 {
+		return bits
+	}
+	if stream.BitsPerSample > 0 {
+		return stream.BitsPerSample
+	}
+
+	switch stream.SampleFmt {
+	case "u8", "u8p":
+		return 8
+	case "s16", "s16p":
+		return 16
+	case "s32", "s32p", "flt", "fltp":
+		return 32
+	case "s64", "s64p", "dbl", "dblp":
+		return 64
+	}
+	return 0
+}
+
+func parseFloat(value string) float64 {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
+
+// ffprobe kare hızı ("30000/1001", "25/1") → fps
+func parseFrameRate(value string) float64 {
+	num, den, found := strings.Cut(value, "/")
+	if !found {
+		return parseFloat(value)
+	}
+	denominator := parseFloat(den)
+	if denominator == 0 {
+		return 0
+	}
+	return parseFloat(num) / denominator
+}
+
+func parseInt(value string) int64 {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
@@ -0,0 +1,81 @@
+package audio
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+)
+
+// Silence - seste sessizlik aralığı (saniye)
+type Silence struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// Duration - sessizliğin süresi
+func (s Silence) Duration() float64 {
+	return s.End - s.Start
+}
+
+// ffmpeg silencedetect satırları:
+//
+//	[silencedetect @ 0x...] silence_start: 12.345
+//	[silencedetect @ 0x...] silence_end: 14.1 | silence_duration: 1.755
+var (
+	silenceStartPattern = regexp.MustCompile(`silence_start: (-?[0-9.]+)`)
+	silenceEndPattern   = regexp.MustCompile(`silence_end: (-?[0-9.]+)`)
+)
+
+// DetectSilences - ffmpeg silencedetect filtresiyle sessizlikleri bulma
+//
+// thresholdDB altındaki seviye en az minDuration saniye sürerse sessizlik
+// sayılır. Dosya sessizlikle bitiyorsa son aralık duration'da kapatılır.
+func DetectSilences(filePath string, duration float64, thresholdDB float64, minDuration float64) ([]Silence, error) {
+	filter := fmt.Sprintf("silencedetect=noise=%sdB:d=%s",
+		strconv.FormatFloat(thresholdDB, 'f', -1, 64), strconv.FormatFloat(minDuration, 'f', -1, 64))
+	cmd := exec.Command("ffmpeg", "-hide_banner", "-nostats", "-i", filePath, "-af", filter, "-f", "null", "-")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("FFmpeg sessizlik tespiti hatası: %w\nÇıktı: %s", err, string(output))
+	}
+
+	return parseSilenceDetect(string(output), duration), nil
+}
+
+// silencedetect çıktısından sessizlik aralıkları
+func parseSilenceDetect(output string, duration float64) []Silence {
+	starts := silenceStartPattern.FindAllStringSubmatchIndex(output, -1)
+	ends := silenceEndPattern.FindAllStringSubmatchIndex(output, -1)
+
+	var silences []Silence
+	endIndex := 0
+	for _, start := range starts {
+		startTime, err := strconv.ParseFloat(output[start[2]:start[3]], 64)
+		if err != nil {
+			continue
+		}
+		if startTime < 0 {
+			startTime = 0
+		}
+
+		// bu başlangıçtan sonraki ilk bitiş
+		for endIndex < len(ends) && ends[endIndex][0] < start[0] {
+			endIndex++
+		}
+		endTime := duration
+		if endIndex < len(ends) {
+			if value, err := strconv.ParseFloat(output[ends[endIndex][2]:ends[endIndex][3]], 64); err == nil {
+				endTime = value
+			}
+			endIndex++
+		}
+
+		if endTime > startTime {
+			silences = append(silences, Silence{Start: startTime, End: endTime})
+		}
+	}
+
+	return silences
+}
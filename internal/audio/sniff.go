@@ -0,0 +1,157 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// içerik tespiti için okunan baş kısım (Ogg ilk sayfası ve WAV fmt chunk'ı sığar)
+const sniffHeaderSize = 512
+
+// sniffFile - dosyanın ilk baytlarından (magic bytes) container ve codec tespiti
+//
+// TANINAN FORMATLAR:
+// - RIFF....WAVE          → wav  (fmt chunk'ından pcm/pcm_float/mp3...)
+// - fLaC                  → flac
+// - ID3 / MPEG frame sync → mp3  (ID3 etiketi atlanıp frame kontrol edilir)
+// - OggS                  → ogg  (ilk sayfadan opus/vorbis/flac)
+// - ....ftyp              → m4a, mp4 veya mov (marka kodundan)
+// - ADTS sync (0xFFF)     → aac
+// - EBML (1A 45 DF A3)    → webm veya mkv (DocType'tan)
+//
+// Tanınmayan içerik için boş string döner.
+func sniffFile(filePath string) (container string, codec string, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", "", fmt.Errorf("dosya açılamadı: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, sniffHeaderSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", "", fmt.Errorf("dosya okunamadı: %w", err)
+	}
+	header = header[:n]
+
+	// ID3v2 etiketi: asıl ses verisi etiketin arkasında başlar
+	if bytes.HasPrefix(header, []byte("ID3")) && len(header) >= 10 {
+		tagSize := int64(synchsafe(header[6:10])) + 10
+		frame := make([]byte, 4)
+		if _, err := file.ReadAt(frame, tagSize); err != nil {
+			return "mp3", "mp3", nil // etiket var ama frame okunamadı; ID3 neredeyse her zaman mp3
+		}
+		if container, codec := sniffFrameSync(frame); container != "" {
+			return container, codec, nil
+		}
+		return "mp3", "mp3", nil
+	}
+
+	container, codec = sniffHeader(header)
+	return container, codec, nil
+}
+
+func sniffHeader(header []byte) (string, string) {
+	switch {
+	case len(header) >= 12 && bytes.Equal(header[0:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE")):
+		return "wav", wavCodec(header)
+
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		return "flac", "flac"
+
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return "ogg", oggCodec(header)
+
+	case len(header) >= 12 && bytes.Equal(header[4:8], []byte("ftyp")):
+		return ftypContainer(header[8:12]), ""
+
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return ebmlContainer(header), ""
+	}
+
+	return sniffFrameSync(header)
+}
+
+// MPEG audio / ADTS frame sync (11-12 bit 1)
+func sniffFrameSync(frame []byte) (string, string) {
+	if len(frame) < 2 || frame[0] != 0xFF || frame[1]&0xE0 != 0xE0 {
+		return "", ""
+	}
+
+	layer := (frame[1] >> 1) & 0x03
+	switch {
+	case frame[1]&0xF6 == 0xF0: // MPEG-2/4 sync + layer 00 → ADTS
+		return "aac", "aac"
+	case layer == 0x01:
+		return "mp3", "mp3"
+	case layer == 0x02:
+		return "mp3", "mp2"
+	}
+	return "", ""
+}
+
+// WAV fmt chunk'ındaki format kodundan codec
+func wavCodec(header []byte) string {
+	if len(header) < 22 || !bytes.Equal(header[12:16], []byte("fmt ")) {
+		return "pcm"
+	}
+
+	switch binary.LittleEndian.Uint16(header[20:22]) {
+	case 0x0003:
+		return "pcm_float"
+	case 0x0006:
+		return "pcm_alaw"
+	case 0x0007:
+		return "pcm_mulaw"
+	case 0x0055:
+		return "mp3"
+	default:
+		return "pcm"
+	}
+}
+
+// Ogg ilk sayfasındaki codec başlığı
+func oggCodec(header []byte) string {
+	switch {
+	case bytes.Contains(header, []byte("OpusHead")):
+		return "opus"
+	case bytes.Contains(header, []byte("\x01vorbis")):
+		return "vorbis"
+	case bytes.Contains(header, []byte("\x7FFLAC")):
+		return "flac"
+	case bytes.Contains(header, []byte("Speex   ")):
+		return "speex"
+	}
+	return ""
+}
+
+// ISO BMFF marka kodundan container
+func ftypContainer(brand []byte) string {
+	switch string(brand) {
+	case "M4A ", "M4B ", "M4P ":
+		return "m4a"
+	case "qt  ":
+		return "mov"
+	default:
+		return "mp4"
+	}
+}
+
+// Matroska EBML başlığındaki DocType ("webm" veya "matroska")
+//
+// DocType elemanı (ID 0x4282) başlığın ilk birkaç baytı içindedir; tam bir
+// EBML parser'ına gerek yoktur.
+func ebmlContainer(header []byte) string {
+	if i := bytes.Index(header, []byte{0x42, 0x82}); i >= 0 && i+3 <= len(header) && bytes.HasPrefix(header[i+3:], []byte("webm")) {
+		return "webm"
+	}
+	return "mkv"
+}
+
+// ID3v2 boyut alanı: her baytın en üst biti 0 olan 4x7 bit
+func synchsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7F)<<21 | uint32(b[1]&0x7F)<<14 | uint32(b[2]&0x7F)<<7 | uint32(b[3]&0x7F)
+}
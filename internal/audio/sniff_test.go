@@ -0,0 +1,116 @@
+package audio
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// RIFF/WAVE + fmt chunk'ının format kodu
+func wavHeader(format uint16) []byte {
+	header := []byte("RIFF\x00\x00\x00\x00WAVEfmt \x10\x00\x00\x00")
+	return binary.LittleEndian.AppendUint16(header, format)
+}
+
+func TestSniffHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    []byte
+		container string
+		codec     string
+	}{
+		{"wav pcm", wavHeader(0x0001), "wav", "pcm"},
+		{"wav float", wavHeader(0x0003), "wav", "pcm_float"},
+		{"wav mp3", wavHeader(0x0055), "wav", "mp3"},
+		{"wav fmt'siz", []byte("RIFF\x00\x00\x00\x00WAVE"), "wav", "pcm"},
+		{"flac", []byte("fLaC\x00\x00\x00\x22"), "flac", "flac"},
+		{"ogg opus", []byte("OggS\x00\x02" + strings.Repeat("\x00", 22) + "OpusHead"), "ogg", "opus"},
+		{"ogg vorbis", []byte("OggS\x00\x02" + strings.Repeat("\x00", 22) + "\x01vorbis"), "ogg", "vorbis"},
+		{"m4a", []byte("\x00\x00\x00\x20ftypM4A \x00\x00\x00\x00"), "m4a", ""},
+		{"mov", []byte("\x00\x00\x00\x14ftypqt  \x00\x00\x00\x00"), "mov", ""},
+		{"mp4", []byte("\x00\x00\x00\x18ftypisom\x00\x00\x02\x00"), "mp4", ""},
+		{"webm", []byte("\x1A\x45\xDF\xA3\x9F\x42\x86\x81\x01\x42\x82\x84webm"), "webm", ""},
+		{"mkv", []byte("\x1A\x45\xDF\xA3\x9F\x42\x86\x81\x01\x42\x82\x88matroska"), "mkv", ""},
+		{"mp3 frame", []byte{0xFF, 0xFB, 0x90, 0x64}, "mp3", "mp3"},
+		{"mp2 frame", []byte{0xFF, 0xFD, 0x90, 0x64}, "mp3", "mp2"},
+		{"aac adts", []byte{0xFF, 0xF1, 0x50, 0x80}, "aac", "aac"},
+		{"tanınmayan", []byte("merhaba dünya"), "", ""},
+		{"boş", nil, "", ""},
+	}
+	for _, test := range tests {
+		container, codec := sniffHeader(test.header)
+		if container != test.container || codec != test.codec {
+			t.Errorf("%s: %q/%q, beklenen %q/%q", test.name, container, codec, test.container, test.codec)
+		}
+	}
+}
+
+// ID3 etiketi atlanıp arkasındaki frame'e bakılmalı
+func TestSniffFileSkipsID3(t *testing.T) {
+	dir := t.TempDir()
+	tag := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x14"), make([]byte, 20)...) // 20 baytlık etiket
+
+	tests := []struct {
+		name      string
+		frame     []byte
+		container string
+		codec     string
+	}{
+		{"mp3", []byte{0xFF, 0xFB, 0x90, 0x64}, "mp3", "mp3"},
+		{"aac", []byte{0xFF, 0xF1, 0x50, 0x80}, "aac", "aac"},
+		{"frame yok", nil, "mp3", "mp3"},
+	}
+	for _, test := range tests {
+		path := filepath.Join(dir, test.name+".bin")
+		if err := os.WriteFile(path, append(append([]byte(nil), tag...), test.frame...), 0644); err != nil {
+			t.Fatal(err)
+		}
+		container, codec, err := sniffFile(path)
+		if err != nil || container != test.container || codec != test.codec {
+			t.Errorf("%s: %q/%q (%v), beklenen %q/%q", test.name, container, codec, err, test.container, test.codec)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	mp4 := []byte("\x00\x00\x00\x18ftypisom\x00\x00\x02\x00")
+
+	tests := []struct {
+		name      string
+		path      string
+		container string
+		warning   string // uyarıda geçmesi beklenen metin; boşsa uyarı olmamalı
+	}{
+		{"uzantı ile içerik aynı", write("ders.wav", wavHeader(1)), "wav", ""},
+		{"aynı aile", write("ders.m4a", mp4), "mp4", ""},
+		{"uzantı yanlış", write("ders.mp3", wavHeader(1)), "wav", "uzantısı .mp3 ama içerik wav (pcm)"},
+		{"içerik tanınmıyor", write("ders.aiff", []byte("FORM")), "aiff", "uzantıya (.aiff) güveniliyor"},
+		{"uzantı da yok", write("ders", []byte("FORM")), "", "uzantısı yok"},
+	}
+	for _, test := range tests {
+		container, _, warning, err := detectFormat(test.path)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if container != test.container {
+			t.Errorf("%s: container %q, beklenen %q", test.name, container, test.container)
+		}
+		if (test.warning == "") != (warning == "") || !strings.Contains(warning, test.warning) {
+			t.Errorf("%s: uyarı %q, beklenen %q", test.name, warning, test.warning)
+		}
+	}
+
+	if _, _, _, err := detectFormat(filepath.Join(dir, "yok.wav")); err == nil {
+		t.Error("olmayan dosya için hata bekleniyordu")
+	}
+}
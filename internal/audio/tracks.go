@@ -0,0 +1,89 @@
+package audio
+
+import (
+	"fmt"
+	"strings"
+
+	"spt2/pkg/models"
+)
+
+// BCP-47 / ISO 639-1 dil kodlarının container'larda kullanılan ISO 639-2
+// karşılıkları (bibliyografik ve terminolojik biçimler birlikte)
+var iso639Alpha3 = map[string][]string{
+	"tr": {"tur"},
+	"en": {"eng"},
+	"de": {"deu", "ger"},
+	"fr": {"fra", "fre"},
+	"es": {"spa"},
+	"it": {"ita"},
+	"ar": {"ara"},
+	"ru": {"rus"},
+}
+
+// SelectAudioTrack - birden fazla ses izi olan dosyada FLAC'e çıkarılacak izi seçme
+//
+// SEÇİM SIRASI:
+//  1. trackIndex >= 0 ise o sıradaki ses izi (ffmpeg -map 0:a:N ile aynı sıra)
+//  2. language verilmişse dil etiketi eşleşen ilk iz ("tr", "tr-TR" veya "tur")
+//  3. ikisi de yoksa ExtractMetadata'nın seçtiği varsayılan iz korunur
+func SelectAudioTrack(metadata *models.AudioMetadata, trackIndex int, language string) error {
+	if len(metadata.AudioTracks) == 0 {
+		return nil // ses akışı yok; ValidateMetadata reddeder
+	}
+
+	if trackIndex >= 0 {
+		if trackIndex >= len(metadata.AudioTracks) {
+			return fmt.Errorf("ses izi bulunamadı: %d (dosyada %d ses izi var)", trackIndex, len(metadata.AudioTracks))
+		}
+		applyTrack(metadata, trackIndex)
+		return nil
+	}
+
+	if language != "" {
+		for _, track := range metadata.AudioTracks {
+			if matchesLanguage(track.Language, language) {
+				applyTrack(metadata, track.Index)
+				return nil
+			}
+		}
+		return fmt.Errorf("'%s' dilinde ses izi bulunamadı (mevcut diller: %s)", language, trackLanguages(metadata.AudioTracks))
+	}
+
+	return nil
+}
+
+// iz etiketi ile istenen dilin eşleşmesi
+func matchesLanguage(trackLanguage string, want string) bool {
+	trackLanguage = strings.ToLower(trackLanguage)
+	want = strings.ToLower(want)
+	if trackLanguage == "" {
+		return false
+	}
+	if trackLanguage == want {
+		return true
+	}
+
+	primary, _, _ := strings.Cut(want, "-")
+	if trackLanguage == primary {
+		return true
+	}
+	for _, code := range iso639Alpha3[primary] {
+		if trackLanguage == code {
+			return true
+		}
+	}
+	return false
+}
+
+// hata mesajı için izlerin dil listesi
+func trackLanguages(tracks []models.AudioTrack) string {
+	languages := make([]string, 0, len(tracks))
+	for _, track := range tracks {
+		language := track.Language
+		if language == "" {
+			language = "?"
+		}
+		languages = append(languages, fmt.Sprintf("%d:%s", track.Index, language))
+	}
+	return strings.Join(languages, ", ")
+}
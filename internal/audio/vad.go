@@ -0,0 +1,294 @@
+package audio
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"sort"
+	"strconv"
+
+	"spt2/pkg/models"
+)
+
+// VAD çözümlemesinin sabitleri
+const (
+	vadSampleRate    = 16000 // PCM bu hıza indirilerek çözülür
+	vadFrameDuration = 0.03  // çerçeve süresi (saniye)
+	vadFloorDB       = -55.0 // bu seviyenin altı gürültü tabanından bağımsız olarak sessizlik
+	vadCeilingDB     = -40.0 // bu seviyeyi aşan ses hiçbir zaman "konuşmasız" sayılmaz
+	vadSilenceDB     = -120.0
+	vadNoisePercent  = 0.1 // gürültü tabanı: çerçeve enerjilerinin %10'luk dilimi
+
+	// ötümsüz sessizler (s, ş, f) düşük enerjili ama sık sıfır geçişlidir
+	vadFricativeMinZCR = 0.2
+	vadFricativeMaxZCR = 0.8
+)
+
+// VADOptions - konuşma tespiti ayarları (config: vad_*, silence_min_duration)
+type VADOptions struct {
+	Threshold  float64 // gürültü tabanının kaç dB üstü konuşma sayılır
+	MinSpeech  float64 // bundan kısa konuşma bölgeleri atılır (saniye)
+	MinSilence float64 // bundan kısa sessizlikler konuşma bölgesine katılır (saniye)
+	Padding    float64 // bölgelerin iki yanına eklenen süre (saniye)
+}
+
+// VADOptionsFromConfig - config'teki vad_* alanlarından ayarlar
+func VADOptionsFromConfig(cfg *models.AppConfig) VADOptions {
+	return VADOptions{
+		Threshold:  cfg.VADThreshold,
+		MinSpeech:  cfg.VADMinSpeech,
+		MinSilence: cfg.SilenceMinDuration,
+		Padding:    cfg.VADPadding,
+	}
+}
+
+// VADResult - konuşma tespitinin sonucu
+type VADResult struct {
+	Regions        []models.SpeechRegion
+	Duration       float64 // çözülen PCM'in süresi (saniye)
+	SpeechDuration float64 // bölgelerin toplam süresi
+	NoiseFloor     float64 // tahmini gürültü tabanı (dBFS)
+	PeakEnergy     float64 // en yüksek çerçeve enerjisi (dBFS)
+}
+
+// SpeechRatio - sesin konuşma olan kısmının oranı (0-1)
+func (r *VADResult) SpeechRatio() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return r.SpeechDuration / r.Duration
+}
+
+// Silent - seste konuşma olmadığı kesin mi
+//
+// Bölge bulunamaması tek başına yetmez: hiç duraksamayan, sabit seviyeli bir
+// seste gürültü tabanı konuşmanın kendisidir ve bölge çıkmayabilir. Sessiz
+// sayılmak için en yüksek çerçeve enerjisinin de -40 dBFS'in altında olması gerekir.
+func (r *VADResult) Silent() bool {
+	return len(r.Regions) == 0 && r.PeakEnergy < vadCeilingDB
+}
+
+// DetectSpeech - dosyanın seçili ses izini PCM'e çözüp konuşma bölgelerini bulma
+//
+// Çözme işini ffmpeg yapar (16 kHz, mono, 16 bit); tespit AnalyzePCM ile
+// Go içinde yapılır ve PCM bellekte tutulmadan akış halinde okunur.
+func DetectSpeech(metadata *models.AudioMetadata, options VADOptions) (*VADResult, error) {
+	cmd := exec.Command("ffmpeg", "-v", "error", "-i", metadata.FilePath,
+		"-map", fmt.Sprintf("0:a:%d", metadata.SelectedTrack), "-vn",
+		"-ac", "1", "-ar", strconv.Itoa(vadSampleRate), "-f", "s16le", "-")
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("FFmpeg başlatılamadı: %w", err)
+	}
+	var stderr limitedBuffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("FFmpeg başlatılamadı: %w", err)
+	}
+
+	result, analyzeErr := AnalyzePCM(stdout, vadSampleRate, options)
+	// okuma yarıda kaldıysa ffmpeg'in yazmayı bitirmesi beklenmez
+	io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("FFmpeg PCM çözme hatası: %w\nÇıktı: %s", err, stderr.String())
+	}
+	if analyzeErr != nil {
+		return nil, analyzeErr
+	}
+	return result, nil
+}
+
+// AnalyzePCM - 16 bit little-endian mono PCM'de enerji ve sıfır geçiş
+// oranıyla konuşma bölgelerini bulma
+//
+// ADIMLAR:
+//  1. PCM 30 ms'lik çerçevelere bölünür; her çerçevenin enerjisi (dBFS) ve
+//     sıfır geçiş oranı (ZCR) hesaplanır
+//  2. Gürültü tabanı çerçeve enerjilerinin %10'luk dilimidir; enerjisi
+//     taban + Threshold'u (ve -55 dBFS'i) aşan çerçeveler konuşmadır.
+//     Taban -40 dBFS'in üstündeyse (duraksamasız konuşma) tahmin
+//     güvenilmez sayılır ve eşik -55 dBFS olur. Eşiğin Threshold/2 altına kadar inen ama ZCR'ı yüksek çerçeveler de
+//     (ötümsüz sessizler) konuşma sayılır
+//  3. MinSilence'tan kısa boşluklar birleştirilir, MinSpeech'ten kısa
+//     bölgeler atılır, kalanlar Padding kadar genişletilir
+func AnalyzePCM(reader io.Reader, sampleRate int, options VADOptions) (*VADResult, error) {
+	frameSize := int(float64(sampleRate) * vadFrameDuration)
+	if frameSize < 2 {
+		return nil, fmt.Errorf("geçersiz örnekleme hızı: %d Hz", sampleRate)
+	}
+
+	var energies, zcrs []float64
+	samples := make([]int16, frameSize)
+	frame := make([]byte, 2*frameSize)
+	buffered := bufio.NewReaderSize(reader, 64*1024)
+	totalSamples := 0
+
+	for {
+		n, err := readSamples(buffered, frame, samples)
+		if n > 0 {
+			energy, zcr := frameFeatures(samples[:n])
+			energies = append(energies, energy)
+			zcrs = append(zcrs, zcr)
+			totalSamples += n
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("PCM okunamadı: %w", err)
+		}
+	}
+
+	result := &VADResult{Duration: float64(totalSamples) / float64(sampleRate), PeakEnergy: vadSilenceDB}
+	if len(energies) == 0 {
+		return result, nil
+	}
+
+	result.NoiseFloor = percentile(energies, vadNoisePercent)
+	result.PeakEnergy = percentile(energies, 1)
+	threshold := math.Max(result.NoiseFloor+options.Threshold, vadFloorDB)
+	if result.NoiseFloor >= vadCeilingDB {
+		threshold = vadFloorDB
+	}
+
+	frameDuration := float64(frameSize) / float64(sampleRate)
+	var regions []models.SpeechRegion
+	for i, energy := range energies {
+		speech := energy >= threshold ||
+			(energy >= threshold-options.Threshold/2 && zcrs[i] >= vadFricativeMinZCR && zcrs[i] <= vadFricativeMaxZCR)
+		if !speech {
+			continue
+		}
+
+		start := float64(i) * frameDuration
+		end := math.Min(start+frameDuration, result.Duration)
+		if last := len(regions) - 1; last >= 0 && start-regions[last].End < options.MinSilence {
+			regions[last].End = end
+			continue
+		}
+		regions = append(regions, models.SpeechRegion{Start: start, End: end})
+	}
+
+	for _, region := range regions {
+		if region.End-region.Start < options.MinSpeech {
+			continue
+		}
+		region.Start = math.Max(0, region.Start-options.Padding)
+		region.End = math.Min(result.Duration, region.End+options.Padding)
+
+		if last := len(result.Regions) - 1; last >= 0 && region.Start <= result.Regions[last].End {
+			result.Regions[last].End = region.End
+			continue
+		}
+		result.Regions = append(result.Regions, region)
+	}
+
+	for _, region := range result.Regions {
+		result.SpeechDuration += region.End - region.Start
+	}
+	return result, nil
+}
+
+// samples'ı doldurana kadar (veya akış bitene kadar) örnek okuma
+func readSamples(reader io.Reader, buf []byte, samples []int16) (int, error) {
+	n, err := io.ReadFull(reader, buf)
+	count := n / 2
+	for i := 0; i < count; i++ {
+		samples[i] = int16(binary.LittleEndian.Uint16(buf[2*i:]))
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return count, err
+}
+
+// çerçevenin enerjisi (dBFS) ve sıfır geçiş oranı
+func frameFeatures(samples []int16) (float64, float64) {
+	var sumSquares float64
+	crossings := 0
+	for i, sample := range samples {
+		value := float64(sample) / 32768
+		sumSquares += value * value
+		if i > 0 && (sample >= 0) != (samples[i-1] >= 0) {
+			crossings++
+		}
+	}
+
+	energy := vadSilenceDB
+	if meanSquare := sumSquares / float64(len(samples)); meanSquare > 0 {
+		energy = math.Max(10*math.Log10(meanSquare), vadSilenceDB)
+	}
+	zcr := 0.0
+	if len(samples) > 1 {
+		zcr = float64(crossings) / float64(len(samples)-1)
+	}
+	return energy, zcr
+}
+
+func percentile(values []float64, fraction float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted[int(fraction*float64(len(sorted)-1))]
+}
+
+// RegionSilences - konuşma bölgelerinin [from, to] aralığındaki boşlukları
+//
+// Dönen sessizlikler from'a göre görelidir (kırpılmış FLAC'in zamanı);
+// böylece VAD sonucu PlanChunks'a silencedetect yerine verilebilir.
+func RegionSilences(regions []models.SpeechRegion, from float64, to float64) []Silence {
+	var silences []Silence
+	position := from
+	for _, region := range regions {
+		if region.Start > position {
+			silences = append(silences, Silence{Start: position - from, End: math.Min(region.Start, to) - from})
+		}
+		position = math.Max(position, region.End)
+		if position >= to {
+			break
+		}
+	}
+	if position < to {
+		silences = append(silences, Silence{Start: position - from, End: to - from})
+	}
+
+	valid := silences[:0]
+	for _, silence := range silences {
+		if silence.End > silence.Start {
+			valid = append(valid, silence)
+		}
+	}
+	return valid
+}
+
+// SpeechBounds - ilk konuşmanın başı ve son konuşmanın sonu (baştaki ve
+// sondaki sessizliği kırpmak için); bölge yoksa ok=false
+func SpeechBounds(regions []models.SpeechRegion) (start float64, end float64, ok bool) {
+	if len(regions) == 0 {
+		return 0, 0, false
+	}
+	return regions[0].Start, regions[len(regions)-1].End, true
+}
+
+// ffmpeg stderr'inin ilk 64KB'ı (hata mesajı için)
+type limitedBuffer struct {
+	data []byte
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := 64*1024 - len(b.data); room > 0 {
+		if len(p) < room {
+			room = len(p)
+		}
+		b.data = append(b.data, p[:room]...)
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return string(b.data)
+}
@@ -0,0 +1,97 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var testVADOptions = VADOptions{Threshold: 12, MinSpeech: 0.25, MinSilence: 0.5, Padding: 0.2}
+
+// örnek üreticisinden 16 kHz, 16 bit little-endian PCM
+func testPCM(seconds float64, sample func(t float64) float64) *bytes.Reader {
+	count := int(seconds * vadSampleRate)
+	buf := make([]byte, 2*count)
+	for i := 0; i < count; i++ {
+		value := sample(float64(i) / vadSampleRate)
+		binary.LittleEndian.PutUint16(buf[2*i:], uint16(int16(math.Max(-1, math.Min(1, value))*32767)))
+	}
+	return bytes.NewReader(buf)
+}
+
+func tone(amplitude float64, frequency float64) func(t float64) float64 {
+	return func(t float64) float64 {
+		return amplitude * math.Sin(2*math.Pi*frequency*t)
+	}
+}
+
+func TestAnalyzePCMSilence(t *testing.T) {
+	result, err := AnalyzePCM(testPCM(5, func(float64) float64 { return 0 }), vadSampleRate, testVADOptions)
+	if err != nil {
+		t.Fatalf("AnalyzePCM: %v", err)
+	}
+	if len(result.Regions) != 0 {
+		t.Errorf("sessizlikte bölge bulundu: %v", result.Regions)
+	}
+	if !result.Silent() {
+		t.Errorf("sessizlik Silent sayılmadı (tepe %.1f dBFS)", result.PeakEnergy)
+	}
+	if math.Abs(result.Duration-5) > 0.001 {
+		t.Errorf("süre = %.3f, beklenen 5", result.Duration)
+	}
+}
+
+// duraksamasız sabit seviyeli ses "konuşmasız" sayılmamalı
+func TestAnalyzePCMConstantTone(t *testing.T) {
+	result, err := AnalyzePCM(testPCM(20, tone(0.3, 220)), vadSampleRate, testVADOptions)
+	if err != nil {
+		t.Fatalf("AnalyzePCM: %v", err)
+	}
+	if result.Silent() {
+		t.Fatalf("sabit seviyeli ses Silent sayıldı (taban %.1f, tepe %.1f dBFS)", result.NoiseFloor, result.PeakEnergy)
+	}
+	if len(result.Regions) != 1 {
+		t.Fatalf("bölge sayısı = %d, beklenen 1: %v", len(result.Regions), result.Regions)
+	}
+	if ratio := result.SpeechRatio(); ratio < 0.99 {
+		t.Errorf("konuşma oranı = %.2f, beklenen ~1", ratio)
+	}
+}
+
+func TestAnalyzePCMSpeechWithPauses(t *testing.T) {
+	// 0-2, 4-6, 8-10 sn konuşma; arada ve sonda -60 dBFS civarı gürültü
+	noise := rand.New(rand.NewSource(1))
+	speech := tone(0.3, 220)
+	sample := func(t float64) float64 {
+		background := (noise.Float64()*2 - 1) * 0.001
+		if int(t)%4 < 2 && t < 10 {
+			return speech(t) + background
+		}
+		return background
+	}
+
+	result, err := AnalyzePCM(testPCM(12, sample), vadSampleRate, testVADOptions)
+	if err != nil {
+		t.Fatalf("AnalyzePCM: %v", err)
+	}
+
+	want := [][2]float64{{0, 2.2}, {3.8, 6.2}, {7.8, 10.2}}
+	if len(result.Regions) != len(want) {
+		t.Fatalf("bölge sayısı = %d, beklenen %d: %v", len(result.Regions), len(want), result.Regions)
+	}
+	for i, region := range result.Regions {
+		if math.Abs(region.Start-want[i][0]) > 0.05 || math.Abs(region.End-want[i][1]) > 0.05 {
+			t.Errorf("%d. bölge = %.2f-%.2f, beklenen %.2f-%.2f", i, region.Start, region.End, want[i][0], want[i][1])
+		}
+	}
+	if math.Abs(result.SpeechDuration-7) > 0.15 {
+		t.Errorf("konuşma süresi = %.2f, beklenen ~7", result.SpeechDuration)
+	}
+
+	silences := RegionSilences(result.Regions, 0, result.Duration)
+	if len(silences) != 3 {
+		t.Errorf("sessizlik sayısı = %d, beklenen 3: %v", len(silences), silences)
+	}
+}
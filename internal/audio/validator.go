@@ -14,29 +14,96 @@ var supportedFormats = map[string]bool{
 	"ogg" : true,
 	"opus": true,
 	"aac" : true,
+	"mp4" : true,
+	//video container'ları: ses izi FLAC'e çıkarılır
+	"mov" : true,
+	"mkv" : true,
+	"webm": true,
 }
 
 func isFormatSupported(format string) bool{
 	return supportedFormats[format]
 }
 
+//uzantısı desteklenen bir formata ait mi (dizin taramalarında aday dosya seçimi için;
+//asıl karar içerik tespitinden sonra ValidateMetadata'da verilir)
+func IsSupportedExtension(filePath string) bool{
+	ext := extensionFormat(filePath)
+	if _, ok := formatFamilies[ext]; ok {
+		return true
+	}
+	return supportedFormats[ext]
+}
+
+//video dosyaları büyük olabilir; yalnızca ses izi dönüştürülüp yüklendiği için
+//limit daha yüksek tutulur
+const (
+	maxAudioFileSize = 500 * 1024 * 1024
+	maxVideoFileSize = 4 * 1024 * 1024 * 1024
+)
+
+//süre limitleri (saniye)
+const (
+	minDuration = 0.1
+	maxDuration = 480 * 60 //LongRunningRecognize limiti: 480 dakika
+)
+
+//parçalara bölünerek tanınan seslerde API limitleri parça başına uygulanır;
+//kaynak dosya için yalnızca makul bir üst sınır kalır
+const (
+	maxChunkedFileSize = maxVideoFileSize
+	maxChunkedDuration = 24 * 60 * 60
+)
+
 //ses dosya metadata geçerliliği için
-func ValidateMetadata(metadata *models.AudioMetadata) error{
+//geçersizse IsValid=false ve ValidationError doldurulur
+//
+//chunking açıksa (bkz. ChunkingEnabled) boyut ve süre limitleri
+//parçalı tanımanın limitleriyle değiştirilir
+func ValidateMetadata(metadata *models.AudioMetadata, cfg *models.AppConfig) error{
+	if err := validateMetadata(metadata, ChunkingEnabled(cfg)); err != nil {
+		metadata.IsValid = false
+		metadata.ValidationError = err.Error()
+		return err
+	}
+	return nil
+}
+
+func validateMetadata(metadata *models.AudioMetadata, chunked bool) error{
 	if !isFormatSupported(metadata.OriginalFormat) {
-		return fmt.Errorf("desteklenmeyen ses formatı: %s \n (desteklenenler: mp3, wav, flac, m4a, ogg, opus, aac)", metadata.OriginalFormat)
+		return fmt.Errorf("desteklenmeyen ses formatı: %s \n (desteklenenler: mp3, wav, flac, m4a, mp4, ogg, opus, aac, mov, mkv, webm)", metadata.OriginalFormat)
 	}
 
-	const maxFileSize = 500 * 1024 * 1024
+	maxFileSize := int64(maxAudioFileSize)
+	if metadata.HasVideo {
+		maxFileSize = maxVideoFileSize
+	}
+	if chunked {
+		maxFileSize = maxChunkedFileSize
+	}
 	if metadata.FileSize <= 0 {
 		return fmt.Errorf("geçersiz dosya boyutu: %d bytes", metadata.FileSize)
 	}
 	if metadata.FileSize > maxFileSize {
-		return fmt.Errorf("dosya çok büyük: %d bytes (maksimum: %d bytes / 500MB)", metadata.FileSize, maxFileSize)
+		return fmt.Errorf("dosya çok büyük: %d bytes (maksimum: %d MB)", metadata.FileSize, maxFileSize/(1024*1024))
 	}
 
 	if metadata.FilePath == "" {
 		return fmt.Errorf("dosya yolu boş")
 	}
 
+	if metadata.AudioStreams == 0 {
+		return fmt.Errorf("dosyada ses akışı bulunamadı")
+	}
+	if metadata.Duration < minDuration {
+		return fmt.Errorf("ses süresi çok kısa: %.2f sn (minimum: %.1f sn)", metadata.Duration, minDuration)
+	}
+	if chunked && metadata.Duration > maxChunkedDuration {
+		return fmt.Errorf("ses süresi çok uzun: %.0f sn (maksimum: %d sn / 24 saat)", metadata.Duration, maxChunkedDuration)
+	}
+	if !chunked && metadata.Duration > maxDuration {
+		return fmt.Errorf("ses süresi çok uzun: %.0f sn (maksimum: %d sn / 480 dakika; daha uzun sesler için chunking: auto)", metadata.Duration, maxDuration)
+	}
+
 	return nil
 }
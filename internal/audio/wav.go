@@ -0,0 +1,129 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// WAV fmt chunk'ındaki format kodları
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+// WAVInfo - WAV dosyasının fmt ve data chunk'larındaki ses özellikleri
+type WAVInfo struct {
+	Format        uint16 // fmt chunk format kodu (extensible ise alt format)
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+	ByteRate      int64
+	DataSize      int64
+}
+
+// Duration - data chunk boyutundan süre (saniye); bilinmiyorsa 0
+func (info *WAVInfo) Duration() float64 {
+	if info.ByteRate == 0 {
+		return 0
+	}
+	return float64(info.DataSize) / float64(info.ByteRate)
+}
+
+// Codec - ffprobe'un codec adlandırmasıyla (pcm_s16le, pcm_f32le, ...)
+func (info *WAVInfo) Codec() string {
+	switch {
+	case info.Format == wavFormatFloat:
+		return fmt.Sprintf("pcm_f%dle", info.BitsPerSample)
+	case info.Format == wavFormatPCM && info.BitsPerSample == 8:
+		return "pcm_u8"
+	case info.Format == wavFormatPCM:
+		return fmt.Sprintf("pcm_s%dle", info.BitsPerSample)
+	}
+	return ""
+}
+
+// ReadWAVInfo - WAV header'ını (RIFF/WAVE, fmt ve data chunk'ları) okuma
+//
+// Chunk'lar sırayla gezilir: her biri 4 bayt ID + 4 bayt little-endian boyut
+// ve (tek boyutluysa 1 bayt dolguyla) içerikten oluşur. fmt içinde:
+// format kodu (2), kanal (2), örnekleme hızı (4), bayt hızı (4), blok (2),
+// örnek başına bit (2). data chunk'ının içeriği okunmaz, boyutu alınır.
+func ReadWAVInfo(filePath string) (*WAVInfo, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("WAV dosyası açılamadı: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return nil, fmt.Errorf("WAV header okunamadı: %w", err)
+	}
+	if !bytes.Equal(header[0:4], []byte("RIFF")) || !bytes.Equal(header[8:12], []byte("WAVE")) {
+		return nil, fmt.Errorf("geçerli bir WAV dosyası değil: %s", filePath)
+	}
+
+	var info *WAVInfo
+	chunk := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(file, chunk); err != nil {
+			return nil, fmt.Errorf("WAV dosyasında data chunk'ı yok: %s", filePath)
+		}
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+		skip := size + size%2
+
+		switch string(chunk[0:4]) {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("WAV fmt chunk'ı eksik: %s", filePath)
+			}
+			body := make([]byte, size)
+			if _, err := io.ReadFull(file, body); err != nil {
+				return nil, fmt.Errorf("WAV fmt chunk'ı okunamadı: %w", err)
+			}
+			info = &WAVInfo{
+				Format:        binary.LittleEndian.Uint16(body[0:2]),
+				Channels:      int(binary.LittleEndian.Uint16(body[2:4])),
+				SampleRate:    int(binary.LittleEndian.Uint32(body[4:8])),
+				ByteRate:      int64(binary.LittleEndian.Uint32(body[8:12])),
+				BitsPerSample: int(binary.LittleEndian.Uint16(body[14:16])),
+			}
+			// WAVE_FORMAT_EXTENSIBLE: alt formatın ilk iki baytı format kodudur
+			if info.Format == wavFormatExtensible && size >= 26 {
+				info.Format = binary.LittleEndian.Uint16(body[24:26])
+			}
+			skip = size % 2
+		case "data":
+			if info == nil {
+				return nil, fmt.Errorf("WAV dosyasında data chunk'ı fmt'den önce: %s", filePath)
+			}
+			// akış halinde yazılmış WAV'larda boyut alanı 0xFFFFFFFF olabilir
+			if remaining, err := remainingBytes(file); err == nil && size > remaining {
+				size = remaining
+			}
+			info.DataSize = size
+			return info, nil
+		}
+
+		if _, err := file.Seek(skip, io.SeekCurrent); err != nil {
+			return nil, fmt.Errorf("WAV dosyası okunamadı: %w", err)
+		}
+	}
+}
+
+// dosyada okuma konumundan sonra kalan bayt sayısı
+func remainingBytes(file *os.File) (int64, error) {
+	position, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return fileInfo.Size() - position, nil
+}
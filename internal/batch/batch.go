@@ -0,0 +1,254 @@
+// Package batch, bir dizindeki veya glob desenine uyan çok sayıda ses
+// dosyasını sınırlı sayıda worker ile paralel olarak deşifre eder ve
+// başarılı/başarısız dosyaların özet raporunu üretir.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"spt2/internal/audio"
+	"spt2/internal/pipeline"
+)
+
+// dosya durumları
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped" // iptal edildiği için hiç başlatılmadı
+)
+
+// Input - işlenecek bir dosya
+type Input struct {
+	Path   string
+	RelDir string // çıktıların output_dir altındaki alt dizini (dizin taramasında kaynak yapısı korunur)
+}
+
+// FileResult - tek bir dosyanın sonucu
+type FileResult struct {
+	Path          string    `json:"path"`
+	Status        string    `json:"status"`
+	Error         string    `json:"error,omitempty"`
+	StartedAt     time.Time `json:"started_at,omitempty"`
+	Elapsed       float64   `json:"elapsed_seconds"`
+	AudioDuration float64   `json:"audio_duration"`
+	SpeechRatio   float64   `json:"speech_ratio,omitempty"` // konuşma tespiti yapıldıysa
+	OutputFiles   []string  `json:"output_files,omitempty"`
+}
+
+// Report - toplu çalışmanın özeti
+type Report struct {
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt time.Time    `json:"finished_at"`
+	Workers    int          `json:"workers"`
+	Total      int          `json:"total"`
+	Succeeded  int          `json:"succeeded"`
+	Failed     int          `json:"failed"`
+	Skipped    int          `json:"skipped"`
+	Files      []FileResult `json:"files"`
+}
+
+// RunFunc - tek bir dosyayı uçtan uca işleyen fonksiyon (genelde pipeline.Run)
+type RunFunc func(ctx context.Context, input Input) (*pipeline.Result, error)
+
+// ProgressFunc - her dosya bittiğinde çağrılır (done: biten dosya sayısı)
+type ProgressFunc func(result FileResult, done int, total int)
+
+// CollectInputs - dizin, glob deseni veya dosya yollarından işlenecek dosyaları toplar
+//
+// Dizinler alt dizinleriyle birlikte taranır ve yalnızca desteklenen
+// uzantılar alınır; glob ve tek dosya girdileri olduğu gibi kullanılır.
+// skipDir (genelde output_dir) altındaki dosyalar atlanır, böylece önceki
+// çalışmaların FLAC çıktıları tekrar işlenmez.
+func CollectInputs(patterns []string, skipDir string) ([]Input, error) {
+	skipAbs := ""
+	if skipDir != "" {
+		skipAbs, _ = filepath.Abs(skipDir)
+	}
+
+	seen := make(map[string]bool)
+	var inputs []Input
+	add := func(path string, relDir string) {
+		abs, err := filepath.Abs(path)
+		if err != nil || seen[abs] {
+			return
+		}
+		if skipAbs != "" && (abs == skipAbs || strings.HasPrefix(abs, skipAbs+string(filepath.Separator))) {
+			return
+		}
+		seen[abs] = true
+		inputs = append(inputs, Input{Path: path, RelDir: relDir})
+	}
+
+	for _, pattern := range patterns {
+		info, err := os.Stat(pattern)
+		if err == nil && info.IsDir() {
+			root := pattern
+			err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if entry.IsDir() || !audio.IsSupportedExtension(path) {
+					return nil
+				}
+				relDir, err := filepath.Rel(root, filepath.Dir(path))
+				if err != nil || relDir == "." {
+					relDir = ""
+				}
+				add(path, relDir)
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("dizin taranamadı (%s): %w", root, err)
+			}
+			continue
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("geçersiz glob deseni (%s): %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("eşleşen dosya yok: %s", pattern)
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() {
+				add(match, "")
+			}
+		}
+	}
+
+	return inputs, nil
+}
+
+// CheckOutputConflicts - çıktıları aynı dosyaya yazılacak girdileri bulma
+//
+// Çıktılar <output_dir>/<RelDir>/<kaynak_adı>.<format> olarak yazılır; aynı
+// dizindeki ders.wav ile ders.mp3 veya farklı dizinlerden glob ile verilen
+// iki ders.wav birbirinin çıktılarını ezer. Worker'lar başlamadan çağrılır,
+// çakışma varsa hiçbir dosya işlenmeden hata döner.
+func CheckOutputConflicts(inputs []Input) error {
+	owners := make(map[string]string)
+	var conflicts []string
+	for _, input := range inputs {
+		name := filepath.Base(input.Path)
+		key := filepath.Join(input.RelDir, strings.TrimSuffix(name, filepath.Ext(name)))
+		if first, ok := owners[key]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s ve %s (%s.*)", first, input.Path, key))
+			continue
+		}
+		owners[key] = input.Path
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("aynı çıktı adına yazılacak dosyalar var, yeniden adlandırın veya ayrı çalıştırın: %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+// Run - dosyaları en fazla `workers` eşzamanlı iş ile işler
+//
+// Bir dosyanın hatası (panic dahil) diğerlerini durdurmaz; sonucu raporda
+// "failed" olarak yer alır. ctx iptal edilirse başlamamış dosyalar "skipped"
+// olarak işaretlenir. Report.Files, girdi sırasını korur.
+func Run(ctx context.Context, inputs []Input, workers int, run RunFunc, progress ProgressFunc) *Report {
+	if workers < 1 {
+		workers = 1
+	}
+
+	report := &Report{
+		StartedAt: time.Now(),
+		Workers:   workers,
+		Total:     len(inputs),
+		Files:     make([]FileResult, len(inputs)),
+	}
+
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	done := 0
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := runOne(ctx, inputs[i], run)
+
+				mu.Lock()
+				report.Files[i] = result
+				done++
+				if progress != nil {
+					progress(result, done, len(inputs))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	next := 0
+dispatch:
+	for next < len(inputs) {
+		select {
+		case jobs <- next:
+			next++
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i := next; i < len(inputs); i++ {
+		report.Files[i] = FileResult{Path: inputs[i].Path, Status: StatusSkipped, Error: ctx.Err().Error()}
+	}
+
+	for _, file := range report.Files {
+		switch file.Status {
+		case StatusSucceeded:
+			report.Succeeded++
+		case StatusFailed:
+			report.Failed++
+		case StatusSkipped:
+			report.Skipped++
+		}
+	}
+	report.FinishedAt = time.Now()
+
+	return report
+}
+
+// tek dosyayı işleme; panic de hata olarak raporlanır
+func runOne(ctx context.Context, input Input, run RunFunc) (result FileResult) {
+	result = FileResult{Path: input.Path, StartedAt: time.Now()}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Status = StatusFailed
+			result.Error = fmt.Sprintf("panic: %v", recovered)
+		}
+		result.Elapsed = time.Since(result.StartedAt).Seconds()
+	}()
+
+	pipelineResult, err := run(ctx, input)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = StatusSucceeded
+	result.OutputFiles = pipelineResult.OutputFiles
+	if pipelineResult.Metadata != nil {
+		result.AudioDuration = pipelineResult.Metadata.Duration
+		result.SpeechRatio = pipelineResult.Metadata.SpeechRatio
+	}
+	return result
+}
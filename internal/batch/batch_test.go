@@ -0,0 +1,33 @@
+package batch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckOutputConflicts(t *testing.T) {
+	inputs := []Input{
+		{Path: "kayitlar/a/ders.wav", RelDir: "a"},
+		{Path: "kayitlar/b/ders.wav", RelDir: "b"},
+		{Path: "kayitlar/a/sunum.wav", RelDir: "a"},
+	}
+	if err := CheckOutputConflicts(inputs); err != nil {
+		t.Fatalf("farklı alt dizinler çakışmamalı: %v", err)
+	}
+
+	// aynı dizinde farklı uzantı ve glob ile farklı dizinlerden aynı ad
+	inputs = append(inputs,
+		Input{Path: "kayitlar/a/ders.mp3", RelDir: "a"},
+		Input{Path: "eski/toplanti.wav"},
+		Input{Path: "yeni/toplanti.wav"},
+	)
+	err := CheckOutputConflicts(inputs)
+	if err == nil {
+		t.Fatal("çakışma hatası bekleniyordu")
+	}
+	for _, want := range []string{"kayitlar/a/ders.wav ve kayitlar/a/ders.mp3", "eski/toplanti.wav ve yeni/toplanti.wav"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("hata %q içermeli: %v", want, err)
+		}
+	}
+}
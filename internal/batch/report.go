@@ -0,0 +1,71 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteReport - özet raporu output dizinine JSON ve TXT olarak yazar
+//
+// Dosya adları çalışmanın başlangıç zamanını içerir
+// (batch-report-20240610-150405.json), böylece önceki raporların üzerine yazılmaz.
+func WriteReport(report *Report, outputDir string) (jsonPath string, txtPath string, err error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", "", fmt.Errorf("output dizini oluşturulamadı: %w", err)
+	}
+
+	baseName := "batch-report-" + report.StartedAt.Format("20060102-150405")
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", "", fmt.Errorf("rapor JSON'u oluşturulamadı: %w", err)
+	}
+	jsonPath = filepath.Join(outputDir, baseName+".json")
+	if err := os.WriteFile(jsonPath, jsonData, 0644); err != nil {
+		return "", "", fmt.Errorf("rapor JSON dosyası yazılamadı: %w", err)
+	}
+
+	txtPath = filepath.Join(outputDir, baseName+".txt")
+	if err := os.WriteFile(txtPath, []byte(FormatReport(report)), 0644); err != nil {
+		return "", "", fmt.Errorf("rapor TXT dosyası yazılamadı: %w", err)
+	}
+
+	return jsonPath, txtPath, nil
+}
+
+// FormatReport - raporun okunabilir metin hali
+func FormatReport(report *Report) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Başlangıç: %s\n", report.StartedAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("Bitiş: %s\n", report.FinishedAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("Süre: %.1f sn (%d worker)\n", report.FinishedAt.Sub(report.StartedAt).Seconds(), report.Workers))
+	sb.WriteString(fmt.Sprintf("Toplam: %d dosya, %d başarılı, %d başarısız, %d atlandı\n\n",
+		report.Total, report.Succeeded, report.Failed, report.Skipped))
+
+	sb.WriteString("--- BAŞARILI ---\n\n")
+	for _, file := range report.Files {
+		if file.Status != StatusSucceeded {
+			continue
+		}
+		if file.SpeechRatio > 0 {
+			sb.WriteString(fmt.Sprintf("%s (ses: %.1f sn, konuşma: %%%.0f, işlem: %.1f sn)\n", file.Path, file.AudioDuration, file.SpeechRatio*100, file.Elapsed))
+		} else {
+			sb.WriteString(fmt.Sprintf("%s (ses: %.1f sn, işlem: %.1f sn)\n", file.Path, file.AudioDuration, file.Elapsed))
+		}
+	}
+
+	if report.Failed > 0 || report.Skipped > 0 {
+		sb.WriteString("\n--- BAŞARISIZ / ATLANAN ---\n\n")
+		for _, file := range report.Files {
+			if file.Status != StatusSucceeded {
+				sb.WriteString(fmt.Sprintf("%s [%s]\n    %s\n", file.Path, file.Status, file.Error))
+			}
+		}
+	}
+
+	return sb.String()
+}
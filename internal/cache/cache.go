@@ -0,0 +1,307 @@
+// Package cache, deşifre sonuçlarını ses içeriğinin hash'i ve tanıma
+// ayarlarıyla adreslenen bir dizinde saklar. Aynı kayıt aynı ayarlarla
+// tekrar işlendiğinde dönüştürme, yükleme ve (ücretli) API çağrısı atlanır.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"spt2/pkg/models"
+)
+
+// Settings - sonucu etkileyen config alanları (cache anahtarının parçası)
+//
+// Çıktı ayarları (SRT, TXT, output_dir ...) burada yoktur; onları değiştirmek
+// cache'i geçersiz kılmaz.
+type Settings struct {
+	Backend                    string   `json:"backend"`
+	FakeResultFile             string   `json:"fake_result_file,omitempty"`
+	SpeechEndpoint             string   `json:"speech_endpoint,omitempty"`
+	LanguageCode               string   `json:"language_code"`
+	AlternativeLanguageCodes   []string `json:"alternative_language_codes,omitempty"`
+	Model                      string   `json:"model"`
+	UseEnhanced                bool     `json:"use_enhanced"`
+	SpeechContexts             []string `json:"speech_contexts,omitempty"`
+	BoostValue                 float64  `json:"boost_value"`
+	EnableDiarization          bool     `json:"enable_diarization"`
+	MinSpeakers                int      `json:"min_speakers"`
+	MaxSpeakers                int      `json:"max_speakers"`
+	EnableAutomaticPunctuation bool     `json:"enable_automatic_punctuation"`
+	EnableWordTimeOffsets      bool     `json:"enable_word_time_offsets"`
+	EnableWordConfidence       bool     `json:"enable_word_confidence"`
+	MaxAlternatives            int      `json:"max_alternatives"`
+	ProfanityFilter            bool     `json:"profanity_filter"`
+	TargetSampleRate           int      `json:"target_sample_rate"`
+	ConvertToMono              bool     `json:"convert_to_mono"`
+	MultiChannel               bool     `json:"multi_channel"`
+	Chunking                   string   `json:"chunking"`
+	ChunkMaxDuration           float64  `json:"chunk_max_duration"`
+	ChunkOverlap               float64  `json:"chunk_overlap"`
+	SilenceThreshold           float64  `json:"silence_threshold"`
+	SilenceMinDuration         float64  `json:"silence_min_duration"`
+	EnableVAD                  bool     `json:"enable_vad"`
+	VADThreshold               float64  `json:"vad_threshold"`
+	VADMinSpeech               float64  `json:"vad_min_speech"`
+	VADPadding                 float64  `json:"vad_padding"`
+	TrimSilence                bool     `json:"trim_silence"`
+	PreprocessHighpass         float64  `json:"preprocess_highpass"`
+	PreprocessDenoise          string   `json:"preprocess_denoise"`
+	PreprocessDenoiseStrength  float64  `json:"preprocess_denoise_strength"`
+	PreprocessDenoiseModel     string   `json:"preprocess_denoise_model,omitempty"`
+	PreprocessLoudnorm         bool     `json:"preprocess_loudnorm"`
+	PreprocessLoudnormTarget   float64  `json:"preprocess_loudnorm_target"`
+	AudioTrack                 int      `json:"audio_track"`
+	AudioLanguage              string   `json:"audio_language,omitempty"`
+}
+
+// SettingsFor - config'in sonucu etkileyen alanları
+func SettingsFor(cfg *models.AppConfig) Settings {
+	return Settings{
+		Backend:                    cfg.Backend,
+		FakeResultFile:             cfg.FakeResultFile,
+		SpeechEndpoint:             cfg.SpeechEndpoint,
+		LanguageCode:               cfg.LanguageCode,
+		AlternativeLanguageCodes:   cfg.AlternativeLanguageCodes,
+		Model:                      cfg.Model,
+		UseEnhanced:                cfg.UseEnhanced,
+		SpeechContexts:             cfg.SpeechContexts,
+		BoostValue:                 cfg.BoostValue,
+		EnableDiarization:          cfg.EnableDiarization,
+		MinSpeakers:                cfg.MinSpeakers,
+		MaxSpeakers:                cfg.MaxSpeakers,
+		EnableAutomaticPunctuation: cfg.EnableAutomaticPunctuation,
+		EnableWordTimeOffsets:      cfg.EnableWordTimeOffsets,
+		EnableWordConfidence:       cfg.EnableWordConfidence,
+		MaxAlternatives:            cfg.MaxAlternatives,
+		ProfanityFilter:            cfg.ProfanityFilter,
+		TargetSampleRate:           cfg.TargetSampleRate,
+		ConvertToMono:              cfg.ConvertToMono,
+		MultiChannel:               cfg.MultiChannel,
+		Chunking:                   cfg.Chunking,
+		ChunkMaxDuration:           cfg.ChunkMaxDuration,
+		ChunkOverlap:               cfg.ChunkOverlap,
+		SilenceThreshold:           cfg.SilenceThreshold,
+		SilenceMinDuration:         cfg.SilenceMinDuration,
+		EnableVAD:                  cfg.EnableVAD,
+		VADThreshold:               cfg.VADThreshold,
+		VADMinSpeech:               cfg.VADMinSpeech,
+		VADPadding:                 cfg.VADPadding,
+		TrimSilence:                cfg.TrimSilence,
+		PreprocessHighpass:         cfg.PreprocessHighpass,
+		PreprocessDenoise:          cfg.PreprocessDenoise,
+		PreprocessDenoiseStrength:  cfg.PreprocessDenoiseStrength,
+		PreprocessDenoiseModel:     cfg.PreprocessDenoiseModel,
+		PreprocessLoudnorm:         cfg.PreprocessLoudnorm,
+		PreprocessLoudnormTarget:   cfg.PreprocessLoudnormTarget,
+		AudioTrack:                 cfg.AudioTrack,
+		AudioLanguage:              cfg.AudioLanguage,
+	}
+}
+
+// Entry - cache'teki tek bir kayıt
+type Entry struct {
+	Key         string                      `json:"key"`
+	ContentHash string                      `json:"content_hash"` // ses dosyasının sha256'sı
+	AudioFile   string                      `json:"audio_file"`   // kaydı oluşturan dosyanın yolu
+	AudioSize   int64                       `json:"audio_size"`
+	Settings    Settings                    `json:"settings"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	Metadata    *models.AudioMetadata       `json:"metadata,omitempty"`
+	Result      *models.TranscriptionResult `json:"result"`
+}
+
+// BrokenEntry - okunamayan veya bozuk cache dosyası (List atlar, raporlar)
+type BrokenEntry struct {
+	Path string
+	Err  error
+}
+
+func (b *BrokenEntry) Error() string {
+	return fmt.Sprintf("%s: %v", filepath.Base(b.Path), b.Err)
+}
+
+// Cache - <dir>/<key>.json dosyalarından oluşan sonuç cache'i
+type Cache struct {
+	dir string
+}
+
+// yeni cache oluşturma (dizin ilk kayıtta oluşturulur)
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Dir - cache dizini
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// HashFile - dosya içeriğinin sha256'sı (hex)
+func HashFile(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("dosya açılamadı: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("dosya okunamadı: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ShortKey - listelerde gösterilen kısa anahtar (ilk 12 karakter)
+func ShortKey(key string) string {
+	if len(key) > 12 {
+		return key[:12]
+	}
+	return key
+}
+
+// geçerli anahtar: 64 karakter küçük harf hex (sha256)
+func validKey(key string) bool {
+	if len(key) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil && strings.ToLower(key) == key
+}
+
+// Key - içerik hash'i ve ayarlardan cache anahtarı
+func Key(contentHash string, settings Settings) string {
+	settingsJSON, _ := json.Marshal(settings)
+
+	hash := sha256.New()
+	hash.Write([]byte(contentHash))
+	hash.Write([]byte{'\n'})
+	hash.Write(settingsJSON)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Get - anahtarla kayıt okuma (yoksa nil, nil)
+func (c *Cache) Get(key string) (*Entry, error) {
+	entry, err := c.load(c.entryPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return entry, err
+}
+
+// Put - kaydı diske yazar (önce geçici dosyaya, sonra rename)
+func (c *Cache) Put(entry *Entry) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("cache dizini oluşturulamadı: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cache kaydı oluşturulamadı: %w", err)
+	}
+
+	path := c.entryPath(entry.Key)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("cache kaydı yazılamadı: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("cache kaydı yazılamadı: %w", err)
+	}
+	return nil
+}
+
+// List - tüm kayıtlar, en yeniden eskiye
+//
+// Okunamayan veya bozuk dosyalar listeyi bozmaz; atlanır ve broken olarak
+// döner (çağıran uyarı verir, `cache evict -all` bunları da siler).
+func (c *Cache) List() (entries []*Entry, broken []*BrokenEntry, err error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("cache dizini okunamadı: %w", err)
+	}
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || filepath.Ext(dirEntry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(c.dir, dirEntry.Name())
+		entry, err := c.load(path)
+		if err != nil {
+			broken = append(broken, &BrokenEntry{Path: path, Err: err})
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt.After(entries[j].CreatedAt) })
+	return entries, broken, nil
+}
+
+// Find - anahtarın başından (en az 4 karakter) kayıt bulma
+func (c *Cache) Find(keyPrefix string) (*Entry, error) {
+	if len(keyPrefix) < 4 {
+		return nil, fmt.Errorf("anahtar en az 4 karakter olmalı: %q", keyPrefix)
+	}
+
+	entries, _, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Entry
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Key, keyPrefix) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("anahtar birden fazla kayıtla eşleşiyor: %s", keyPrefix)
+		}
+		found = entry
+	}
+	if found == nil {
+		return nil, fmt.Errorf("cache kaydı bulunamadı: %s", keyPrefix)
+	}
+	return found, nil
+}
+
+// Delete - kaydı silme
+func (c *Cache) Delete(key string) error {
+	if err := os.Remove(c.entryPath(key)); err != nil {
+		return fmt.Errorf("cache kaydı silinemedi: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) load(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("cache kaydı parse edilemedi (%s): %w", filepath.Base(path), err)
+	}
+	// anahtar silme ve kısaltma için kullanılır; dosya adıyla aynı sha256 olmalı
+	if !validKey(entry.Key) || entry.Key+".json" != filepath.Base(path) {
+		return nil, fmt.Errorf("cache kaydının anahtarı geçersiz (%s): %q", filepath.Base(path), entry.Key)
+	}
+	return &entry, nil
+}
+
+func (c *Cache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spt2/pkg/models"
+)
+
+const testContentHash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func testConfig() *models.AppConfig {
+	return &models.AppConfig{
+		Backend:                    "google",
+		LanguageCode:               "tr-TR",
+		AlternativeLanguageCodes:   []string{"en-US"},
+		Model:                      "default",
+		SpeechContexts:             []string{"API", "REST"},
+		BoostValue:                 10,
+		MinSpeakers:                1,
+		MaxSpeakers:                6,
+		EnableAutomaticPunctuation: true,
+		EnableWordTimeOffsets:      true,
+		MaxAlternatives:            1,
+		TargetSampleRate:           16000,
+		ConvertToMono:              true,
+		Chunking:                   "auto",
+		ChunkMaxDuration:           1800,
+		ChunkOverlap:               1,
+		SilenceThreshold:           -35,
+		SilenceMinDuration:         0.5,
+		VADThreshold:               12,
+		VADMinSpeech:               0.25,
+		VADPadding:                 0.2,
+		PreprocessDenoise:          "none",
+		PreprocessDenoiseStrength:  12,
+		PreprocessLoudnormTarget:   -23,
+		AudioTrack:                 -1,
+		OutputDir:                  "./output",
+		GenerateSRT:                true,
+	}
+}
+
+func TestKeyStableForEqualConfigs(t *testing.T) {
+	first := Key(testContentHash, SettingsFor(testConfig()))
+	second := Key(testContentHash, SettingsFor(testConfig()))
+	if first != second {
+		t.Fatalf("aynı ayarlar farklı anahtar üretti: %s, %s", first, second)
+	}
+	if !validKey(first) {
+		t.Errorf("anahtar 64 hex karakter olmalı: %q", first)
+	}
+
+	// çıktı ayarları anahtarı değiştirmemeli
+	cfg := testConfig()
+	cfg.OutputDir = "/baska/dizin"
+	cfg.GenerateSRT = false
+	cfg.SubtitleMaxCPS = 21
+	if Key(testContentHash, SettingsFor(cfg)) != first {
+		t.Error("çıktı ayarları cache anahtarını değiştirmemeli")
+	}
+
+	if Key(strings.Repeat("0", 64), SettingsFor(testConfig())) == first {
+		t.Error("farklı içerik aynı anahtarı üretti")
+	}
+}
+
+func TestKeyChangesWithRecognitionSettings(t *testing.T) {
+	base := Key(testContentHash, SettingsFor(testConfig()))
+
+	tests := []struct {
+		name   string
+		change func(cfg *models.AppConfig)
+	}{
+		{"backend", func(cfg *models.AppConfig) { cfg.Backend = "fake" }},
+		{"fake_result_file", func(cfg *models.AppConfig) { cfg.FakeResultFile = "testdata/baska.json" }},
+		{"speech_endpoint", func(cfg *models.AppConfig) { cfg.SpeechEndpoint = "127.0.0.1:9090" }},
+		{"language_code", func(cfg *models.AppConfig) { cfg.LanguageCode = "en-US" }},
+		{"alternative_language_codes", func(cfg *models.AppConfig) { cfg.AlternativeLanguageCodes = []string{"de-DE"} }},
+		{"model", func(cfg *models.AppConfig) { cfg.Model = "video" }},
+		{"use_enhanced", func(cfg *models.AppConfig) { cfg.UseEnhanced = true }},
+		{"speech_contexts", func(cfg *models.AppConfig) { cfg.SpeechContexts = []string{"API"} }},
+		{"boost_value", func(cfg *models.AppConfig) { cfg.BoostValue = 15 }},
+		{"enable_diarization", func(cfg *models.AppConfig) { cfg.EnableDiarization = true }},
+		{"min_speakers", func(cfg *models.AppConfig) { cfg.MinSpeakers = 2 }},
+		{"max_speakers", func(cfg *models.AppConfig) { cfg.MaxSpeakers = 3 }},
+		{"enable_automatic_punctuation", func(cfg *models.AppConfig) { cfg.EnableAutomaticPunctuation = false }},
+		{"enable_word_time_offsets", func(cfg *models.AppConfig) { cfg.EnableWordTimeOffsets = false }},
+		{"enable_word_confidence", func(cfg *models.AppConfig) { cfg.EnableWordConfidence = true }},
+		{"max_alternatives", func(cfg *models.AppConfig) { cfg.MaxAlternatives = 3 }},
+		{"profanity_filter", func(cfg *models.AppConfig) { cfg.ProfanityFilter = true }},
+		{"target_sample_rate", func(cfg *models.AppConfig) { cfg.TargetSampleRate = 48000 }},
+		{"convert_to_mono", func(cfg *models.AppConfig) { cfg.ConvertToMono = false }},
+		{"multi_channel", func(cfg *models.AppConfig) { cfg.MultiChannel = true }},
+		{"chunking", func(cfg *models.AppConfig) { cfg.Chunking = "never" }},
+		{"chunk_max_duration", func(cfg *models.AppConfig) { cfg.ChunkMaxDuration = 900 }},
+		{"chunk_overlap", func(cfg *models.AppConfig) { cfg.ChunkOverlap = 2 }},
+		{"silence_threshold", func(cfg *models.AppConfig) { cfg.SilenceThreshold = -40 }},
+		{"silence_min_duration", func(cfg *models.AppConfig) { cfg.SilenceMinDuration = 1 }},
+		{"enable_vad", func(cfg *models.AppConfig) { cfg.EnableVAD = true }},
+		{"vad_threshold", func(cfg *models.AppConfig) { cfg.VADThreshold = 8 }},
+		{"vad_min_speech", func(cfg *models.AppConfig) { cfg.VADMinSpeech = 0.5 }},
+		{"vad_padding", func(cfg *models.AppConfig) { cfg.VADPadding = 0.4 }},
+		{"trim_silence", func(cfg *models.AppConfig) { cfg.TrimSilence = true }},
+		{"preprocess_highpass", func(cfg *models.AppConfig) { cfg.PreprocessHighpass = 80 }},
+		{"preprocess_denoise", func(cfg *models.AppConfig) { cfg.PreprocessDenoise = "afftdn" }},
+		{"preprocess_denoise_strength", func(cfg *models.AppConfig) { cfg.PreprocessDenoiseStrength = 20 }},
+		{"preprocess_denoise_model", func(cfg *models.AppConfig) { cfg.PreprocessDenoiseModel = "model.rnnn" }},
+		{"preprocess_loudnorm", func(cfg *models.AppConfig) { cfg.PreprocessLoudnorm = true }},
+		{"preprocess_loudnorm_target", func(cfg *models.AppConfig) { cfg.PreprocessLoudnormTarget = -16 }},
+		{"audio_track", func(cfg *models.AppConfig) { cfg.AudioTrack = 1 }},
+		{"audio_language", func(cfg *models.AppConfig) { cfg.AudioLanguage = "tur" }},
+	}
+	for _, test := range tests {
+		cfg := testConfig()
+		test.change(cfg)
+		if Key(testContentHash, SettingsFor(cfg)) == base {
+			t.Errorf("%s değişince cache anahtarı değişmedi", test.name)
+		}
+	}
+}
+
+func putEntry(t *testing.T, c *Cache, contentHash string, createdAt time.Time) *Entry {
+	t.Helper()
+	entry := &Entry{
+		Key:         Key(contentHash, SettingsFor(testConfig())),
+		ContentHash: contentHash,
+		AudioFile:   "ders.wav",
+		CreatedAt:   createdAt,
+		Result:      &models.TranscriptionResult{Transcript: "merhaba"},
+	}
+	if err := c.Put(entry); err != nil {
+		t.Fatal(err)
+	}
+	return entry
+}
+
+func TestPutGetFindDelete(t *testing.T) {
+	c := New(filepath.Join(t.TempDir(), "cache"))
+
+	if entry, err := c.Get(Key(testContentHash, SettingsFor(testConfig()))); entry != nil || err != nil {
+		t.Fatalf("boş cache: %v, %v", entry, err)
+	}
+
+	older := putEntry(t, c, testContentHash, time.Now().Add(-time.Hour))
+	newer := putEntry(t, c, strings.Repeat("1", 64), time.Now())
+
+	entry, err := c.Get(older.Key)
+	if err != nil || entry == nil || entry.Result.Transcript != "merhaba" {
+		t.Fatalf("Get: %+v, %v", entry, err)
+	}
+
+	entries, broken, err := c.List()
+	if err != nil || len(broken) != 0 || len(entries) != 2 || entries[0].Key != newer.Key {
+		t.Fatalf("List: %d kayıt, %d bozuk, %v (en yeni önce olmalı)", len(entries), len(broken), err)
+	}
+
+	found, err := c.Find(newer.Key[:8])
+	if err != nil || found.Key != newer.Key {
+		t.Errorf("Find(%s): %v", newer.Key[:8], err)
+	}
+	if _, err := c.Find(newer.Key[:3]); err == nil {
+		t.Error("4 karakterden kısa önek reddedilmeli")
+	}
+	if _, err := c.Find("ffffffff"); err == nil {
+		t.Error("eşleşmeyen önek için hata bekleniyordu")
+	}
+
+	if err := c.Delete(older.Key); err != nil {
+		t.Fatal(err)
+	}
+	if entry, _ := c.Get(older.Key); entry != nil {
+		t.Error("silinen kayıt hâlâ okunuyor")
+	}
+	if entries, _, _ := c.List(); len(entries) != 1 {
+		t.Errorf("silme sonrası %d kayıt, beklenen 1", len(entries))
+	}
+}
+
+// bozuk veya anahtarı geçersiz dosyalar listeyi bozmamalı
+func TestListSkipsBrokenEntries(t *testing.T) {
+	dir := t.TempDir()
+	c := New(dir)
+	good := putEntry(t, c, testContentHash, time.Now())
+
+	shortKey := strings.Repeat("a", 64) + ".json"
+	files := map[string]string{
+		"bozuk.json": "{yarım",
+		"kisa.json":  `{"key": "abc"}`,
+		shortKey:     `{"key": "` + strings.Repeat("b", 64) + `"}`, // dosya adıyla uyuşmuyor
+		"notlar.txt": "json değil, atlanmalı",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, broken, err := c.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Key != good.Key {
+		t.Errorf("%d geçerli kayıt, beklenen 1", len(entries))
+	}
+	if len(broken) != 3 {
+		t.Errorf("%d bozuk kayıt, beklenen 3: %v", len(broken), broken)
+	}
+
+	if _, err := c.Find(good.Key[:6]); err != nil {
+		t.Errorf("bozuk kayıtlar Find'ı bozmamalı: %v", err)
+	}
+	if _, err := c.Get("kisa"); err == nil {
+		t.Error("geçersiz anahtarlı kayıt için hata bekleniyordu")
+	}
+}
+
+func TestShortKey(t *testing.T) {
+	if got := ShortKey("abc"); got != "abc" {
+		t.Errorf("ShortKey(abc) = %q", got)
+	}
+	if got := ShortKey(strings.Repeat("c", 64)); got != strings.Repeat("c", 12) {
+		t.Errorf("ShortKey = %q", got)
+	}
+}
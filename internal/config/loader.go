@@ -4,8 +4,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"spt2/internal/language"
 	"spt2/pkg/models"
 
 	"github.com/go-playground/validator/v10"
@@ -29,24 +32,78 @@ func LoadConfig(path string) (*models.AppConfig, error) {
 	// --- BÖLÜM 1: DEFAULT DEĞERLER (Viper ile) ---
 	
 	// Zorunlu olmayan field'lar için varsayılan değerler
+	viper.SetDefault("backend", "google")
+	viper.SetDefault("alternative_language_codes", []string{})
+	viper.SetDefault("fake_result_file", "")
+	viper.SetDefault("speech_endpoint", "")
 	viper.SetDefault("use_enhanced", false)
 	viper.SetDefault("enable_diarization", false)
+	viper.SetDefault("multi_channel", false)
+	viper.SetDefault("channel_names", []string{})
 	viper.SetDefault("min_speakers", 1)
 	viper.SetDefault("max_speakers", 6)
 	viper.SetDefault("boost_value", 10.0)
+	viper.SetDefault("keyword_fuzzy", false)
+	viper.SetDefault("keyword_max_edits", 1)
+	viper.SetDefault("keyword_context_words", 5)
+	viper.SetDefault("keyword_morphology", "auto")
 	viper.SetDefault("min_confidence", 0.7)
 	viper.SetDefault("max_alternatives", 1)
 	viper.SetDefault("profanity_filter", false)
+	viper.SetDefault("low_confidence", map[string]string{})
+	viper.SetDefault("sync_recognition", "auto")
+	viper.SetDefault("sync_max_duration", 55.0)
 	viper.SetDefault("target_sample_rate", 16000)
 	viper.SetDefault("convert_to_mono", true)
 	viper.SetDefault("chunk_size", 4096)
+	viper.SetDefault("chunking", "auto")
+	viper.SetDefault("chunk_max_duration", 1800.0) // 30 dk: 2-4 saatlik kayıt chunk_workers ile 4-8 paralel parça
+	viper.SetDefault("chunk_overlap", 1.0)
+	viper.SetDefault("chunk_workers", 4)
+	viper.SetDefault("silence_threshold", -35.0)
+	viper.SetDefault("silence_min_duration", 0.5)
+	viper.SetDefault("enable_vad", false)
+	viper.SetDefault("vad_threshold", 12.0)
+	viper.SetDefault("vad_min_speech", 0.25)
+	viper.SetDefault("vad_padding", 0.2)
+	viper.SetDefault("trim_silence", false)
+	viper.SetDefault("preprocess_highpass", 0.0)
+	viper.SetDefault("preprocess_denoise", "none")
+	viper.SetDefault("preprocess_denoise_strength", 12.0)
+	viper.SetDefault("preprocess_denoise_model", "")
+	viper.SetDefault("preprocess_loudnorm", false)
+	viper.SetDefault("preprocess_loudnorm_target", -23.0)
+	viper.SetDefault("audio_track", -1)
+	viper.SetDefault("audio_language", "")
+	viper.SetDefault("batch_workers", 4)
+	viper.SetDefault("enable_cache", false)
+	viper.SetDefault("cache_dir", "") // boşsa <output_dir>/cache
 	viper.SetDefault("output_dir", "./output")
 	viper.SetDefault("generate_json", true)
 	viper.SetDefault("generate_srt", true)
 	viper.SetDefault("generate_txt", true)
+	viper.SetDefault("generate_vtt", false)
+	viper.SetDefault("subtitle_max_chars_per_line", 42)
+	viper.SetDefault("subtitle_max_lines", 2)
+	viper.SetDefault("subtitle_min_duration", 1.0)
+	viper.SetDefault("subtitle_max_duration", 7.0)
+	viper.SetDefault("subtitle_max_cps", 17.0)
+	viper.SetDefault("subtitle_pause_threshold", 0.8)
+	viper.SetDefault("subtitle_split_on_speaker", true)
+	viper.SetDefault("vtt_position", "")
+	viper.SetDefault("vtt_line", "")
+	viper.SetDefault("vtt_align", "")
+	viper.SetDefault("vtt_voice_tags", true)
+	viper.SetDefault("vtt_karaoke", false)
+	viper.SetDefault("vtt_note", "")
+	viper.SetDefault("vtt_style", "")
 	viper.SetDefault("enable_logging", true)
 	viper.SetDefault("log_level", "info")
-	viper.SetDefault("gcs_bucket", "") // Varsayılan olarak boş bırak, `required` validation bunu yakalayacak
+	viper.SetDefault("gcs_bucket", "") // Varsayılan olarak boş bırak, `required_if` validation bunu yakalayacak
+	viper.SetDefault("storage_backend", "") // boşsa backend'e göre seçilir (bkz. defaultStorageBackend)
+	viper.SetDefault("storage_local_dir", "./output/objects")
+	viper.SetDefault("retention_policy", "forever") // önceki sürümlerdeki gibi; yeni kurulumlarda "delete" önerilir
+	viper.SetDefault("retention_days", 7)
 
 	// --- BÖLÜM 2: VIPER İLE CONFIG DOSYASI YÜKLEME ---
 
@@ -70,6 +127,14 @@ func LoadConfig(path string) (*models.AppConfig, error) {
 		return nil, fmt.Errorf("config parse edilemedi: %w", err)
 	}
 
+	if cfg.StorageBackend == "" {
+		cfg.StorageBackend = defaultStorageBackend(cfg.Backend)
+	}
+	// cache, verilmemişse çıktılarla aynı yerde tutulur
+	if cfg.CacheDir == "" && cfg.OutputDir != "" {
+		cfg.CacheDir = filepath.Join(cfg.OutputDir, "cache")
+	}
+
 	// --- BÖLÜM 3: TXT DOSYALARINDAN KELİMELERİ YÜKLE ---
 
 	// Speech contexts dosyasını yükle (varsa)
@@ -90,6 +155,26 @@ func LoadConfig(path string) (*models.AppConfig, error) {
 		cfg.Keywords = keywords
 	}
 
+	// Dil profillerinin dosyalarını yükle (varsa)
+	for code, profile := range cfg.LanguageProfiles {
+		if profile.SpeechContextsFile != "" {
+			contexts, err := loadTextFile(profile.SpeechContextsFile)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' speech contexts dosyası yüklenemedi: %w", code, err)
+			}
+			profile.SpeechContexts = contexts
+		}
+		if profile.KeywordsFile != "" {
+			keywords, err := loadTextFile(profile.KeywordsFile)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' keywords dosyası yüklenemedi: %w", code, err)
+			}
+			profile.Keywords = keywords
+		}
+		cfg.LanguageProfiles[code] = profile
+	}
+	applyLanguageProfiles(&cfg)
+
 	// --- BÖLÜM 4: VALIDATOR İLE DOĞRULAMA ---
 
 	// Custom validator oluştur (dosya varlığı kontrolü için)
@@ -98,6 +183,10 @@ func LoadConfig(path string) (*models.AppConfig, error) {
 	// Custom validation: dosya varlığı kontrolü
 	validate.RegisterValidation("file", validateFileExists)
 
+	// Struct seviyesinde: Google servisleri kullanılıyorsa credentials zorunlu,
+	// dil ayarları tutarlı olmalı
+	validate.RegisterStructValidation(validateAppConfig, models.AppConfig{})
+
 	// Struct validation
 	if err := validate.Struct(&cfg); err != nil {
 		// Validation hatalarını kullanıcı dostu formata çevir
@@ -176,6 +265,120 @@ func validateFileExists(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+// applyLanguageProfiles - dil profillerindeki kelimeleri config'e aktarma
+//
+// Sabit dilde profil, speech_contexts_file / keywords_file verilmemişse
+// onların yerine geçer. "auto" modunda dil istekten önce bilinmediği için
+// aday dillerin tüm speech contexts'leri birleştirilir; keywords ise
+// deşifreden sonra her segmentin tespit edilen diline göre seçilir
+// (bkz. analysis.Annotate).
+func applyLanguageProfiles(cfg *models.AppConfig) {
+	if cfg.LanguageCode != language.Auto {
+		profile, ok := language.Profile(cfg, cfg.LanguageCode)
+		if !ok {
+			return
+		}
+		if cfg.SpeechContextsFile == "" {
+			cfg.SpeechContexts = profile.SpeechContexts
+		}
+		if cfg.KeywordsFile == "" {
+			cfg.Keywords = profile.Keywords
+		}
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, phrase := range cfg.SpeechContexts {
+		seen[phrase] = true
+	}
+	for _, code := range language.Candidates(cfg) {
+		profile, _ := language.Profile(cfg, code)
+		for _, phrase := range profile.SpeechContexts {
+			if !seen[phrase] {
+				seen[phrase] = true
+				cfg.SpeechContexts = append(cfg.SpeechContexts, phrase)
+			}
+		}
+	}
+}
+
+// validateAppConfig - AppConfig için struct seviyesindeki kontroller
+func validateAppConfig(sl validator.StructLevel) {
+	cfg := sl.Current().Interface().(models.AppConfig)
+
+	validateGoogleSettings(sl, cfg)
+	validateLanguageSettings(sl, cfg)
+
+	// kırpma aralığı VAD'ın bulduğu konuşma bölgelerinden gelir
+	if cfg.TrimSilence && !cfg.EnableVAD {
+		sl.ReportError(cfg.TrimSilence, "TrimSilence", "TrimSilence", "vad_required", "")
+	}
+	// kanallar yalnızca ayrı tanınacaksa korunur; tek kanallı tanımada API
+	// yalnızca ilk kanalı dinler ve diğer kanaldaki konuşma kaybolur
+	if !cfg.ConvertToMono && !cfg.MultiChannel {
+		sl.ReportError(cfg.ConvertToMono, "ConvertToMono", "ConvertToMono", "multi_channel_required", "")
+	}
+	if cfg.PreprocessDenoise == "arnndn" && cfg.PreprocessDenoiseModel == "" {
+		sl.ReportError(cfg.PreprocessDenoiseModel, "PreprocessDenoiseModel", "PreprocessDenoiseModel", "required_if", "PreprocessDenoise arnndn")
+	}
+}
+
+// defaultStorageBackend - storage_backend verilmemişse kullanılacak depolama
+//
+// NEDEN: fake backend GCP hesabı olmadan denemek içindir; GCS varsayılan
+// kalırsa credentials, project_id ve gcs_bucket yine zorunlu olur.
+func defaultStorageBackend(backend string) string {
+	if backend == "fake" {
+		return "memory"
+	}
+	return "gcs"
+}
+
+// validateGoogleSettings - Struct validator: Google kimlik bilgileri kontrolü
+//
+// NEDEN: fake backend + local/memory storage ile uygulama GCP hesabı olmadan
+// çalışabilmeli. Credentials ve ProjectID yalnızca Google Speech (endpoint
+// override yoksa) veya GCS kullanıldığında zorunlu tutulur.
+func validateGoogleSettings(sl validator.StructLevel, cfg models.AppConfig) {
+	usesGoogleSpeech := (cfg.Backend == "" || cfg.Backend == "google") && cfg.SpeechEndpoint == ""
+	usesGCS := cfg.StorageBackend == "" || cfg.StorageBackend == "gcs"
+	if !usesGoogleSpeech && !usesGCS {
+		return
+	}
+
+	if cfg.GoogleCredentialsPath == "" {
+		sl.ReportError(cfg.GoogleCredentialsPath, "GoogleCredentialsPath", "GoogleCredentialsPath", "google_required", "")
+	}
+	if cfg.ProjectID == "" {
+		sl.ReportError(cfg.ProjectID, "ProjectID", "ProjectID", "google_required", "")
+	}
+}
+
+// v1 API'nin kabul ettiği en fazla alternatif dil sayısı
+const maxAlternativeLanguages = 3
+
+// validateLanguageSettings - "auto" için en az iki aday dil; ana dil
+// alternatiflerde tekrar edilmemeli
+//
+// API en fazla 3 alternatif dil kabul eder. "auto" modunda listenin ilk dili
+// ana dil olduğundan 4 dile izin verilir, sabit dilde en fazla 3.
+func validateLanguageSettings(sl validator.StructLevel, cfg models.AppConfig) {
+	if cfg.LanguageCode == language.Auto {
+		if len(cfg.AlternativeLanguageCodes) < 2 {
+			sl.ReportError(cfg.AlternativeLanguageCodes, "AlternativeLanguageCodes", "AlternativeLanguageCodes", "auto_languages", "")
+		}
+		return
+	}
+	if len(cfg.AlternativeLanguageCodes) > maxAlternativeLanguages {
+		sl.ReportError(cfg.AlternativeLanguageCodes, "AlternativeLanguageCodes", "AlternativeLanguageCodes", "alternative_languages", strconv.Itoa(maxAlternativeLanguages))
+	}
+	for _, code := range cfg.AlternativeLanguageCodes {
+		if code == cfg.LanguageCode {
+			sl.ReportError(cfg.AlternativeLanguageCodes, "AlternativeLanguageCodes", "AlternativeLanguageCodes", "primary_language", cfg.LanguageCode)
+		}
+	}
+}
+
 // formatValidationErrors - Validation hatalarını okunabilir formata çevir
 //
 // ÖRNEK ÇIKTI:
@@ -204,6 +407,24 @@ func formatValidationErrors(errs validator.ValidationErrors) error {
 			msg = fmt.Sprintf("%s: '%v' çok büyük, maksimum: %s", field, err.Value(), err.Param())
 		case "eq":
 			msg = fmt.Sprintf("%s: '%v' olmalı, '%s' değeri bekleniyor", field, err.Value(), err.Param())
+		case "required_if":
+			msg = fmt.Sprintf("%s: zorunlu field (%s olduğunda)", field, err.Param())
+		case "google_required":
+			msg = fmt.Sprintf("%s: google backend veya gcs storage kullanılırken zorunlu", field)
+		case "gt":
+			msg = fmt.Sprintf("%s: '%v' geçersiz, %s değerinden büyük olmalı", field, err.Value(), err.Param())
+		case "endswith":
+			msg = fmt.Sprintf("%s: '%v' geçersiz, '%s' ile bitmeli", field, err.Value(), err.Param())
+		case "auto_languages":
+			msg = fmt.Sprintf("%s: language_code \"auto\" iken en az 2 dil gerekli", field)
+		case "alternative_languages":
+			msg = fmt.Sprintf("%s: en fazla %s alternatif dil verilebilir (language_code \"auto\" iken 4)", field, err.Param())
+		case "vad_required":
+			msg = fmt.Sprintf("%s: enable_vad açık olmalı", field)
+		case "multi_channel_required":
+			msg = fmt.Sprintf("%s: false ise multi_channel açık olmalı (kanallar ayrı tanınmazsa ilk kanal dışındakiler kaybolur)", field)
+		case "primary_language":
+			msg = fmt.Sprintf("%s: ana dil (%s) alternatiflerde tekrar edilmemeli", field, err.Param())
 		case "gtefield":
 			msg = fmt.Sprintf("%s: %s field'ından büyük veya eşit olmalı", field, err.Param())
 		default:
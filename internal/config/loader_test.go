@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"spt2/internal/audio"
+	"spt2/pkg/models"
+)
+
+// fields'a output_dir eklenerek yazılan config dosyası
+func writeTestConfig(t *testing.T, fields string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	content := "{" + fields + `
+		"output_dir": "` + filepath.ToSlash(filepath.Join(dir, "output")) + `"
+	}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// yalnızca zorunlu alanlarla yazılan config; geri kalanı varsayılanlardan gelir
+func loadTestConfig(t *testing.T, extra string) *models.AppConfig {
+	t.Helper()
+	cfg, err := LoadConfig(writeTestConfig(t, `
+		"backend": "fake",
+		"language_code": "tr-TR",
+		"model": "default",
+		"enable_automatic_punctuation": true,
+		"enable_word_time_offsets": true,
+		`+extra))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	return cfg
+}
+
+// varsayılan chunk süresi uzun kayıtları chunk_workers'ı dolduracak kadar
+// parçaya bölmeli
+func TestDefaultChunkingSplitsLongAudio(t *testing.T) {
+	cfg := loadTestConfig(t, "")
+
+	const fourHours = 4 * 3600.0
+	metadata := &models.AudioMetadata{Duration: fourHours, ConvertedDuration: fourHours}
+	if !audio.ShouldChunk(metadata, cfg) {
+		t.Fatalf("4 saatlik kayıt varsayılan ayarlarla (chunk_max_duration %.0f) bölünmedi", cfg.ChunkMaxDuration)
+	}
+
+	chunks := audio.PlanChunks(fourHours, nil, cfg.ChunkMaxDuration, cfg.ChunkOverlap)
+	if len(chunks) < cfg.ChunkWorkers {
+		t.Errorf("4 saat %d parçaya bölündü, en az %d (chunk_workers) bekleniyordu", len(chunks), cfg.ChunkWorkers)
+	}
+	for _, chunk := range chunks {
+		if chunk.Duration() > cfg.ChunkMaxDuration {
+			t.Errorf("%d. parça %.0f sn, sınır %.0f", chunk.Index, chunk.Duration(), cfg.ChunkMaxDuration)
+		}
+	}
+
+	// 20 dakikalık ders tek parça kalmalı
+	short := &models.AudioMetadata{Duration: 1200, ConvertedDuration: 1200}
+	if audio.ShouldChunk(short, cfg) {
+		t.Error("20 dakikalık kayıt bölünmemeli")
+	}
+}
+
+// kanalları korumak yalnızca her kanal ayrı tanınacaksa anlamlı
+func TestStereoRequiresMultiChannel(t *testing.T) {
+	_, err := LoadConfig(writeTestConfig(t, `
+		"backend": "fake",
+		"language_code": "tr-TR",
+		"model": "default",
+		"enable_automatic_punctuation": true,
+		"enable_word_time_offsets": true,
+		"convert_to_mono": false,`))
+	if err == nil || !strings.Contains(err.Error(), "ConvertToMono") {
+		t.Fatalf("multi_channel olmadan convert_to_mono false reddedilmeli, hata: %v", err)
+	}
+
+	cfg := loadTestConfig(t, `"convert_to_mono": false, "multi_channel": true,`)
+	if cfg.ConvertToMono || !cfg.MultiChannel {
+		t.Errorf("çok kanallı config: convert_to_mono %t, multi_channel %t", cfg.ConvertToMono, cfg.MultiChannel)
+	}
+}
+
+func TestValidateLanguageSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		want   string // hatada geçmesi beklenen alan; boşsa geçerli
+	}{
+		{"sabit dil", `"language_code": "tr-TR", "alternative_language_codes": ["en-US"],`, ""},
+		{"sabit dilde 3 alternatif", `"language_code": "tr-TR", "alternative_language_codes": ["en-US", "de-DE", "fr-FR"],`, ""},
+		{"sabit dilde 4 alternatif", `"language_code": "tr-TR", "alternative_language_codes": ["en-US", "de-DE", "fr-FR", "es-ES"],`, "AlternativeLanguageCodes"},
+		{"ana dil alternatiflerde", `"language_code": "tr-TR", "alternative_language_codes": ["tr-TR"],`, "AlternativeLanguageCodes"},
+		{"auto iki dil", `"language_code": "auto", "alternative_language_codes": ["tr-TR", "en-US"],`, ""},
+		{"auto dört dil", `"language_code": "auto", "alternative_language_codes": ["tr-TR", "en-US", "de-DE", "fr-FR"],`, ""},
+		{"auto tek dil", `"language_code": "auto", "alternative_language_codes": ["tr-TR"],`, "AlternativeLanguageCodes"},
+	}
+	for _, test := range tests {
+		_, err := LoadConfig(writeTestConfig(t, `"backend": "fake", "model": "default", "enable_automatic_punctuation": true, "enable_word_time_offsets": true, `+test.fields))
+		if test.want == "" && err != nil {
+			t.Errorf("%s: beklenmeyen hata: %v", test.name, err)
+		}
+		if test.want != "" && (err == nil || !strings.Contains(err.Error(), test.want)) {
+			t.Errorf("%s: %s hatası bekleniyordu: %v", test.name, test.want, err)
+		}
+	}
+}
+
+func TestApplyLanguageProfiles(t *testing.T) {
+	profiles := map[string]models.LanguageProfile{
+		"tr-TR": {SpeechContexts: []string{"sınav", "vize"}, Keywords: []string{"sınav"}},
+		"en-US": {SpeechContexts: []string{"exam", "vize"}, Keywords: []string{"exam"}},
+	}
+
+	// sabit dilde profil, dosyası verilmeyen listelerin yerine geçer
+	cfg := &models.AppConfig{LanguageCode: "tr-TR", LanguageProfiles: profiles}
+	applyLanguageProfiles(cfg)
+	if strings.Join(cfg.SpeechContexts, ",") != "sınav,vize" || strings.Join(cfg.Keywords, ",") != "sınav" {
+		t.Errorf("tr-TR: contexts %v, keywords %v", cfg.SpeechContexts, cfg.Keywords)
+	}
+
+	cfg = &models.AppConfig{LanguageCode: "tr-TR", LanguageProfiles: profiles,
+		KeywordsFile: "keywords.txt", Keywords: []string{"ödev"}}
+	applyLanguageProfiles(cfg)
+	if strings.Join(cfg.Keywords, ",") != "ödev" {
+		t.Errorf("keywords_file verilmişken profil kullanılmamalı: %v", cfg.Keywords)
+	}
+
+	// auto: adayların speech contexts'leri tekrarsız birleşir, keywords dokunulmaz
+	cfg = &models.AppConfig{LanguageCode: "auto", AlternativeLanguageCodes: []string{"tr-TR", "en-US"},
+		LanguageProfiles: profiles, SpeechContexts: []string{"API"}}
+	applyLanguageProfiles(cfg)
+	if got := strings.Join(cfg.SpeechContexts, ","); got != "API,sınav,vize,exam" {
+		t.Errorf("auto contexts = %s", got)
+	}
+	if len(cfg.Keywords) != 0 {
+		t.Errorf("auto modunda keywords segment diline göre seçilmeli: %v", cfg.Keywords)
+	}
+}
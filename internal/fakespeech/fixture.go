@@ -0,0 +1,207 @@
+package fakespeech
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/speech/apiv1/speechpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Fixture - sahte sunucunun bir istek için döndüreceği senaryo
+//
+// ÖRNEK (testdata/default.json):
+//
+//	{
+//	  "match": "lecture",
+//	  "delay_seconds": 3,
+//	  "results": [
+//	    {
+//	      "result_end_time": 1.2,
+//	      "language_code": "en-us",
+//	      "alternatives": [
+//	        {
+//	          "transcript": "hello world",
+//	          "confidence": 0.93,
+//	          "words": [
+//	            {"word": "hello", "start_time": 0.0, "end_time": 0.5, "confidence": 0.95, "speaker_tag": 1}
+//	          ]
+//	        }
+//	      ]
+//	    }
+//	  ]
+//	}
+type Fixture struct {
+	Name string `json:"-"` // dosya adı (uzantısız)
+
+	// Match - istek URI'sinde geçmesi gereken alt dize.
+	// Boşsa fixture varsayılan senaryo olarak kullanılır.
+	Match string `json:"match"`
+
+	// DelaySeconds - long-running işlemin tamamlanma süresi (yavaş işlemler için)
+	DelaySeconds float64 `json:"delay_seconds"`
+
+	// Error - doluysa işlem bu hata ile sonuçlanır
+	Error *FixtureError `json:"error,omitempty"`
+
+	Results []FixtureResult `json:"results"`
+}
+
+// FixtureError - gRPC durum kodu ve mesajı
+//
+// FailOnSubmit true ise hata LongRunningRecognize çağrısında hemen döner,
+// değilse işlem oluşturulur ve tamamlandığında hata ile biter.
+type FixtureError struct {
+	Code         codes.Code `json:"code"` // "INVALID_ARGUMENT" veya 3
+	Message      string     `json:"message"`
+	FailOnSubmit bool       `json:"fail_on_submit"`
+}
+
+type FixtureResult struct {
+	Alternatives  []FixtureAlternative `json:"alternatives"`
+	ChannelTag    int32                `json:"channel_tag"`
+	ResultEndTime float64              `json:"result_end_time"`
+	LanguageCode  string               `json:"language_code"`
+}
+
+type FixtureAlternative struct {
+	Transcript string        `json:"transcript"`
+	Confidence float32       `json:"confidence"`
+	Words      []FixtureWord `json:"words"`
+}
+
+type FixtureWord struct {
+	Word       string  `json:"word"`
+	StartTime  float64 `json:"start_time"`
+	EndTime    float64 `json:"end_time"`
+	Confidence float32 `json:"confidence"`
+	SpeakerTag int32   `json:"speaker_tag"`
+}
+
+// LoadFixtures - dizindeki tüm *.json fixture dosyalarını isim sırasıyla yükler
+func LoadFixtures(dir string) ([]*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("fixture dizini okunamadı: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("fixture bulunamadı: %s", dir)
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]*Fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("fixture okunamadı '%s': %w", path, err)
+		}
+
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("fixture parse edilemedi '%s': %w", path, err)
+		}
+		fixture.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+
+		fixtures = append(fixtures, &fixture)
+	}
+
+	return fixtures, nil
+}
+
+// istek URI'sine uyan fixture'ı seçme
+//
+// Sıra: Match alanı URI'de geçen ilk fixture → "default" isimli fixture
+// → Match alanı boş olan ilk fixture.
+func selectFixture(fixtures []*Fixture, uri string) *Fixture {
+	if uri != "" {
+		for _, fixture := range fixtures {
+			if fixture.Match != "" && strings.Contains(uri, fixture.Match) {
+				return fixture
+			}
+		}
+	}
+
+	for _, fixture := range fixtures {
+		if fixture.Name == "default" {
+			return fixture
+		}
+	}
+
+	for _, fixture := range fixtures {
+		if fixture.Match == "" {
+			return fixture
+		}
+	}
+
+	return nil
+}
+
+// fixture sonuçlarını API'nin döndürdüğü proto mesajlarına çevirme
+//
+// API gibi sonuç başına en fazla maxAlternatives (0 ise 1) alternatif döner.
+func (f *Fixture) speechResults(maxAlternatives int32) []*speechpb.SpeechRecognitionResult {
+	if maxAlternatives <= 0 {
+		maxAlternatives = 1
+	}
+
+	results := make([]*speechpb.SpeechRecognitionResult, 0, len(f.Results))
+
+	for _, result := range f.Results {
+		pbResult := &speechpb.SpeechRecognitionResult{
+			ChannelTag:    result.ChannelTag,
+			ResultEndTime: seconds(result.ResultEndTime),
+			LanguageCode:  result.LanguageCode,
+		}
+
+		for i, alternative := range result.Alternatives {
+			if int32(i) >= maxAlternatives {
+				break
+			}
+			pbAlternative := &speechpb.SpeechRecognitionAlternative{
+				Transcript: alternative.Transcript,
+				Confidence: alternative.Confidence,
+			}
+
+			for _, word := range alternative.Words {
+				pbAlternative.Words = append(pbAlternative.Words, &speechpb.WordInfo{
+					Word:       word.Word,
+					StartTime:  seconds(word.StartTime),
+					EndTime:    seconds(word.EndTime),
+					Confidence: word.Confidence,
+					SpeakerTag: word.SpeakerTag,
+				})
+			}
+
+			pbResult.Alternatives = append(pbResult.Alternatives, pbAlternative)
+		}
+
+		results = append(results, pbResult)
+	}
+
+	return results
+}
+
+// fixture'daki toplam ses süresi (faturalanan süre olarak döndürülür)
+func (f *Fixture) billedTime() *durationpb.Duration {
+	var end float64
+	for _, result := range f.Results {
+		if result.ResultEndTime > end {
+			end = result.ResultEndTime
+		}
+	}
+	return seconds(end)
+}
+
+func (f *Fixture) delay() time.Duration {
+	return time.Duration(f.DelaySeconds * float64(time.Second))
+}
+
+func seconds(value float64) *durationpb.Duration {
+	return durationpb.New(time.Duration(value * float64(time.Second)))
+}
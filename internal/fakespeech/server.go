@@ -0,0 +1,322 @@
+// Package fakespeech, Google Speech-to-Text v1 API'ını taklit eden yerel bir
+// gRPC sunucusudur. Speech ve longrunning Operations servislerini fixture
+// dosyalarından okunan senaryolarla cevaplar; böylece LongRunningRecognize
+// akışı Google'a bağlanmadan uçtan uca çalıştırılabilir.
+//
+// KULLANIM:
+//
+//	srv, err := fakespeech.Start("127.0.0.1:0", "internal/fakespeech/testdata")
+//	defer srv.Stop()
+//	cfg.SpeechEndpoint = srv.Addr()
+package fakespeech
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"cloud.google.com/go/speech/apiv1/speechpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server - fixture tabanlı sahte Speech sunucusu
+type Server struct {
+	fixtures []*Fixture
+
+	mu         sync.Mutex
+	operations map[string]*operation
+	nextID     int
+
+	listener   net.Listener
+	grpcServer *grpc.Server
+}
+
+// bir long-running işlemin sunucu tarafındaki durumu
+type operation struct {
+	name            string
+	uri             string
+	fixture         *Fixture
+	maxAlternatives int32
+	startedAt       time.Time
+	cancelled       bool
+}
+
+// Start - fixture dizinini yükler ve verilen adreste dinlemeye başlar
+// ("127.0.0.1:0" rastgele boş bir port seçer)
+func Start(addr string, fixtureDir string) (*Server, error) {
+	fixtures, err := LoadFixtures(fixtureDir)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("fake speech sunucusu dinlemeye başlayamadı: %w", err)
+	}
+
+	srv := &Server{
+		fixtures:   fixtures,
+		operations: make(map[string]*operation),
+		listener:   listener,
+		grpcServer: grpc.NewServer(),
+	}
+
+	speechpb.RegisterSpeechServer(srv.grpcServer, &speechService{srv: srv})
+	longrunningpb.RegisterOperationsServer(srv.grpcServer, &operationsService{srv: srv})
+
+	go srv.grpcServer.Serve(listener)
+
+	return srv, nil
+}
+
+// Addr - istemcinin bağlanacağı adres (config'deki speech_endpoint)
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// sunucuyu durdurma
+func (s *Server) Stop() {
+	s.grpcServer.Stop()
+}
+
+// yeni işlem kaydetme
+func (s *Server) startOperation(uri string, fixture *Fixture, maxAlternatives int32) *operation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	op := &operation{
+		name:            fmt.Sprintf("fake-op-%d", s.nextID),
+		uri:             uri,
+		fixture:         fixture,
+		maxAlternatives: maxAlternatives,
+		startedAt:       time.Now(),
+	}
+	s.operations[op.name] = op
+
+	return op
+}
+
+func (s *Server) lookupOperation(name string) (*operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, ok := s.operations[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "işlem bulunamadı: %s", name)
+	}
+	return op, nil
+}
+
+// işlemin o anki durumunu longrunningpb.Operation olarak oluşturma
+func (s *Server) snapshot(op *operation) (*longrunningpb.Operation, error) {
+	s.mu.Lock()
+	cancelled := op.cancelled
+	s.mu.Unlock()
+
+	elapsed := time.Since(op.startedAt)
+	delay := op.fixture.delay()
+	done := cancelled || elapsed >= delay
+
+	progress := int32(100)
+	if !done && delay > 0 {
+		progress = int32(elapsed * 100 / delay)
+	}
+
+	metadata, err := anypb.New(&speechpb.LongRunningRecognizeMetadata{
+		ProgressPercent: progress,
+		StartTime:       timestamppb.New(op.startedAt),
+		LastUpdateTime:  timestamppb.Now(),
+		Uri:             op.uri,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "metadata oluşturulamadı: %v", err)
+	}
+
+	pbOp := &longrunningpb.Operation{
+		Name:     op.name,
+		Metadata: metadata,
+		Done:     done,
+	}
+	if !done {
+		return pbOp, nil
+	}
+
+	switch {
+	case cancelled:
+		pbOp.Result = &longrunningpb.Operation_Error{
+			Error: status.New(codes.Canceled, "işlem iptal edildi").Proto(),
+		}
+	case op.fixture.Error != nil:
+		pbOp.Result = &longrunningpb.Operation_Error{
+			Error: status.New(op.fixture.Error.Code, op.fixture.Error.Message).Proto(),
+		}
+	default:
+		response, err := anypb.New(&speechpb.LongRunningRecognizeResponse{
+			Results:         op.fixture.speechResults(op.maxAlternatives),
+			TotalBilledTime: op.fixture.billedTime(),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "yanıt oluşturulamadı: %v", err)
+		}
+		pbOp.Result = &longrunningpb.Operation_Response{Response: response}
+	}
+
+	return pbOp, nil
+}
+
+// speechpb.SpeechServer implementasyonu
+type speechService struct {
+	speechpb.UnimplementedSpeechServer
+	srv *Server
+}
+
+// Recognize - senkron tanıma; fixture hemen (gecikmesiz) döndürülür
+func (ss *speechService) Recognize(ctx context.Context, req *speechpb.RecognizeRequest) (*speechpb.RecognizeResponse, error) {
+	if err := validateRequest(req.GetConfig(), req.GetAudio()); err != nil {
+		return nil, err
+	}
+
+	fixture := selectFixture(ss.srv.fixtures, req.GetAudio().GetUri())
+	if fixture == nil {
+		return nil, status.Error(codes.NotFound, "istek için uygun fixture yok")
+	}
+	if fixture.Error != nil {
+		return nil, status.Error(fixture.Error.Code, fixture.Error.Message)
+	}
+
+	return &speechpb.RecognizeResponse{
+		Results:         fixture.speechResults(req.GetConfig().GetMaxAlternatives()),
+		TotalBilledTime: fixture.billedTime(),
+	}, nil
+}
+
+// LongRunningRecognize - işlemi başlatır, sonuç GetOperation ile alınır
+func (ss *speechService) LongRunningRecognize(ctx context.Context, req *speechpb.LongRunningRecognizeRequest) (*longrunningpb.Operation, error) {
+	if err := validateRequest(req.GetConfig(), req.GetAudio()); err != nil {
+		return nil, err
+	}
+
+	uri := req.GetAudio().GetUri()
+	fixture := selectFixture(ss.srv.fixtures, uri)
+	if fixture == nil {
+		return nil, status.Errorf(codes.NotFound, "'%s' için uygun fixture yok", uri)
+	}
+	if fixture.Error != nil && fixture.Error.FailOnSubmit {
+		return nil, status.Error(fixture.Error.Code, fixture.Error.Message)
+	}
+
+	return ss.srv.snapshot(ss.srv.startOperation(uri, fixture, req.GetConfig().GetMaxAlternatives()))
+}
+
+// gerçek API'ın temel istek kontrolleri
+func validateRequest(config *speechpb.RecognitionConfig, audio *speechpb.RecognitionAudio) error {
+	if config == nil {
+		return status.Error(codes.InvalidArgument, "config zorunlu")
+	}
+	if config.LanguageCode == "" {
+		return status.Error(codes.InvalidArgument, "language_code zorunlu")
+	}
+	if audio == nil || (audio.GetUri() == "" && len(audio.GetContent()) == 0) {
+		return status.Error(codes.InvalidArgument, "audio uri veya content zorunlu")
+	}
+	return nil
+}
+
+// longrunningpb.OperationsServer implementasyonu
+type operationsService struct {
+	longrunningpb.UnimplementedOperationsServer
+	srv *Server
+}
+
+func (svc *operationsService) GetOperation(ctx context.Context, req *longrunningpb.GetOperationRequest) (*longrunningpb.Operation, error) {
+	op, err := svc.srv.lookupOperation(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return svc.srv.snapshot(op)
+}
+
+func (svc *operationsService) ListOperations(ctx context.Context, req *longrunningpb.ListOperationsRequest) (*longrunningpb.ListOperationsResponse, error) {
+	svc.srv.mu.Lock()
+	ops := make([]*operation, 0, len(svc.srv.operations))
+	for _, op := range svc.srv.operations {
+		ops = append(ops, op)
+	}
+	svc.srv.mu.Unlock()
+
+	sort.Slice(ops, func(i, j int) bool { return ops[i].startedAt.Before(ops[j].startedAt) })
+
+	resp := &longrunningpb.ListOperationsResponse{}
+	for _, op := range ops {
+		pbOp, err := svc.srv.snapshot(op)
+		if err != nil {
+			return nil, err
+		}
+		resp.Operations = append(resp.Operations, pbOp)
+	}
+	return resp, nil
+}
+
+func (svc *operationsService) DeleteOperation(ctx context.Context, req *longrunningpb.DeleteOperationRequest) (*emptypb.Empty, error) {
+	if _, err := svc.srv.lookupOperation(req.GetName()); err != nil {
+		return nil, err
+	}
+
+	svc.srv.mu.Lock()
+	delete(svc.srv.operations, req.GetName())
+	svc.srv.mu.Unlock()
+
+	return &emptypb.Empty{}, nil
+}
+
+func (svc *operationsService) CancelOperation(ctx context.Context, req *longrunningpb.CancelOperationRequest) (*emptypb.Empty, error) {
+	op, err := svc.srv.lookupOperation(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	svc.srv.mu.Lock()
+	op.cancelled = true
+	svc.srv.mu.Unlock()
+
+	return &emptypb.Empty{}, nil
+}
+
+// WaitOperation - işlem bitene ya da timeout dolana kadar bekler
+func (svc *operationsService) WaitOperation(ctx context.Context, req *longrunningpb.WaitOperationRequest) (*longrunningpb.Operation, error) {
+	op, err := svc.srv.lookupOperation(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	if req.GetTimeout() != nil {
+		deadline = time.Now().Add(req.GetTimeout().AsDuration())
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		pbOp, err := svc.srv.snapshot(op)
+		if err != nil || pbOp.Done || time.Now().After(deadline) {
+			return pbOp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
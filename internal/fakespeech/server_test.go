@@ -0,0 +1,181 @@
+package fakespeech_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"spt2/internal/fakespeech"
+	"spt2/internal/speechclient"
+	"spt2/pkg/models"
+)
+
+// rastgele portta başlatılan sunucuya SpeechEndpoint ile bağlanan istemci
+func startClient(t *testing.T, fixtureDir string, cfg *models.AppConfig) *speechclient.SpeechClient {
+	t.Helper()
+	srv, err := fakespeech.Start("127.0.0.1:0", fixtureDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Stop)
+
+	cfg.SpeechEndpoint = srv.Addr()
+	client, err := speechclient.NewSpeechClient(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func testConfig() *models.AppConfig {
+	return &models.AppConfig{
+		LanguageCode:          "en-US",
+		TargetSampleRate:      16000,
+		EnableWordTimeOffsets: true,
+		EnableWordConfidence:  true,
+		MaxAlternatives:       2,
+	}
+}
+
+// VerifyFLAC'in okuyacağı kadar FLAC: fLaC + STREAMINFO (16 bit)
+func writeFLACHeader(t *testing.T, sampleRate int, channels int) string {
+	t.Helper()
+	streamInfo := make([]byte, 34)
+	streamInfo[10] = byte(sampleRate >> 12)
+	streamInfo[11] = byte(sampleRate >> 4)
+	streamInfo[12] = byte(sampleRate<<4) | byte((channels-1)<<1)
+	streamInfo[13] = byte(15 << 4)
+	binary.BigEndian.PutUint32(streamInfo[14:18], uint32(sampleRate))
+
+	path := filepath.Join(t.TempDir(), "ses.flac")
+	data := append([]byte("fLaC"), 0x80, 0, 0, 34)
+	if err := os.WriteFile(path, append(data, streamInfo...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeFixture(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLongRunningRecognizeFixtures(t *testing.T) {
+	cfg := testConfig()
+	client := startClient(t, "testdata", cfg)
+	ctx := context.Background()
+
+	// default.json: kelime zamanları, güven değerleri ve ikinci alternatif
+	result, err := client.LongRunningRecognize(ctx, "gs://spt2/lecture.flac", speechclient.BuildRecognitionConfig(cfg))
+	if err != nil {
+		t.Fatalf("LongRunningRecognize: %v", err)
+	}
+	if want := "An API is a set of rules that lets programs talk to each other."; result.Transcript != want {
+		t.Errorf("transcript = %q, beklenen %q", result.Transcript, want)
+	}
+	if len(result.Words) != 14 || len(result.Segments) != 2 {
+		t.Fatalf("%d kelime, %d segment; beklenen 14, 2", len(result.Words), len(result.Segments))
+	}
+	if word := result.Words[1]; word.Word != "API" || word.StartTime != 0.3 || word.EndTime != 0.9 {
+		t.Errorf("ikinci kelime = %+v", word)
+	}
+	if result.LanguageCode != "en-US" || result.Segments[0].LanguageCode != "en-US" {
+		t.Errorf("dil = %s / %s, beklenen en-US", result.LanguageCode, result.Segments[0].LanguageCode)
+	}
+	alternatives := result.Segments[0].Alternatives
+	if len(alternatives) != 1 || alternatives[0].Transcript != "An APR is a set of rules" {
+		t.Errorf("alternatifler = %+v", alternatives)
+	}
+
+	// max_alternatives 1 iken ikinci alternatif dönmemeli
+	cfg.MaxAlternatives = 1
+	result, err = client.LongRunningRecognize(ctx, "gs://spt2/lecture.flac", speechclient.BuildRecognitionConfig(cfg))
+	if err != nil {
+		t.Fatalf("LongRunningRecognize: %v", err)
+	}
+	if len(result.Segments[0].Alternatives) != 0 {
+		t.Errorf("max_alternatives 1 iken alternatifler = %+v", result.Segments[0].Alternatives)
+	}
+
+	// diarization.json: URI'deki "interview" ile seçilir, kelimeler konuşmacı etiketli
+	cfg.EnableDiarization = true
+	result, err = client.LongRunningRecognize(ctx, "gs://spt2/interview.flac", speechclient.BuildRecognitionConfig(cfg))
+	if err != nil {
+		t.Fatalf("LongRunningRecognize: %v", err)
+	}
+	want := []int32{1, 1, 2, 2, 2, 2}
+	if len(result.Words) != len(want) {
+		t.Fatalf("%d kelime, beklenen %d", len(result.Words), len(want))
+	}
+	for i, word := range result.Words {
+		if word.SpeakerTag != want[i] {
+			t.Errorf("%s: konuşmacı %d, beklenen %d", word.Word, word.SpeakerTag, want[i])
+		}
+	}
+}
+
+func TestScriptedErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "default", `{"results": []}`)
+	writeFixture(t, dir, "corrupt", `{"match": "corrupt", "error": {"code": "INVALID_ARGUMENT", "message": "bozuk FLAC", "fail_on_submit": true}}`)
+	writeFixture(t, dir, "quota", `{"match": "quota", "error": {"code": "RESOURCE_EXHAUSTED", "message": "kota doldu"}}`)
+
+	cfg := testConfig()
+	client := startClient(t, dir, cfg)
+	ctx := context.Background()
+	metadata := &models.AudioMetadata{ConvertedPath: writeFLACHeader(t, 16000, 1)}
+
+	// fail_on_submit: işlem hiç oluşturulmaz
+	if _, err := client.Submit(ctx, metadata, "gs://spt2/corrupt.flac", cfg); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Submit hatası = %v, beklenen InvalidArgument", err)
+	}
+
+	// işlem oluşturulur, tamamlandığında hata ile biter
+	name, err := client.Submit(ctx, metadata, "gs://spt2/quota.flac", cfg)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if _, err := client.Resume(ctx, name, metadata, cfg); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Resume hatası = %v, beklenen ResourceExhausted", err)
+	}
+
+	if _, err := client.Resume(ctx, "fake-op-99", metadata, cfg); status.Code(err) != codes.NotFound {
+		t.Errorf("bilinmeyen işlem hatası = %v, beklenen NotFound", err)
+	}
+}
+
+// slow.json 5 sn sürer; Wait sırasında ctx iptal edilirse hemen dönmeli
+func TestResumeCancelledDuringWait(t *testing.T) {
+	cfg := testConfig()
+	client := startClient(t, "testdata", cfg)
+	metadata := &models.AudioMetadata{ConvertedPath: writeFLACHeader(t, 16000, 1)}
+
+	name, err := client.Submit(context.Background(), metadata, "gs://spt2/slow.flac", cfg)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err = client.Resume(ctx, name, metadata, cfg)
+	if err == nil {
+		t.Fatal("iptal hatası bekleniyordu")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) && status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("hata = %v, beklenen context deadline", err)
+	}
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Errorf("Resume iptalden sonra %.1f sn bekledi", elapsed.Seconds())
+	}
+}
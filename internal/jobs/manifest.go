@@ -0,0 +1,101 @@
+// Package jobs, pipeline çalışmalarının diskteki durum kaydını (manifest)
+// tutar. Süreç yarıda kalırsa (örn. LongRunningRecognize beklenirken) iş,
+// tamamlanan aşamalar atlanarak `spt2 resume` ile devam ettirilebilir.
+package jobs
+
+import (
+	"time"
+
+	"spt2/internal/audio"
+	"spt2/pkg/models"
+)
+
+// Stage - işin tamamlanan son aşaması
+type Stage string
+
+const (
+	StageCreated    Stage = "created"    // kayıt açıldı, henüz bir şey yapılmadı
+	StageConverted  Stage = "converted"  // metadata çıkarıldı, FLAC oluşturuldu
+	StageUploaded   Stage = "uploaded"   // FLAC depolamaya yüklendi (senkron yolda atlanır)
+	StageSubmitted  Stage = "submitted"  // long-running işlem başlatıldı, adı kaydedildi
+	StageRecognized Stage = "recognized" // deşifre sonucu diske yazıldı
+	StageExported   Stage = "exported"   // çıktılar üretildi, iş tamamlandı
+)
+
+// aşamaların sırası
+var stageOrder = []Stage{StageCreated, StageConverted, StageUploaded, StageSubmitted, StageRecognized, StageExported}
+
+func (s Stage) index() int {
+	for i, stage := range stageOrder {
+		if stage == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reached - iş en az verilen aşamaya gelmiş mi
+func (s Stage) Reached(other Stage) bool {
+	return s.index() >= other.index()
+}
+
+// Manifest - tek bir işin diskteki durum kaydı
+type Manifest struct {
+	ID        string `json:"id"`
+	AudioFile string `json:"audio_file"`
+	OutputDir string `json:"output_dir"` // FLAC ve çıktıların yazıldığı dizin
+	Stage     Stage  `json:"stage"`
+
+	Metadata      *models.AudioMetadata `json:"metadata,omitempty"`
+	FLACPath      string                `json:"flac_path,omitempty"`
+	Sync          bool                  `json:"sync"` // senkron tanıma (yükleme yok)
+	ObjectName    string                `json:"object_name,omitempty"`
+	AudioURI      string                `json:"audio_uri,omitempty"`
+	OperationName string                `json:"operation_name,omitempty"`
+	ResultPath    string                `json:"result_path,omitempty"`
+	Chunks        []Chunk               `json:"chunks,omitempty"` // uzun seslerde parçalar (bkz. audio.ShouldChunk)
+	OutputFiles   []string              `json:"output_files,omitempty"`
+
+	ContentHash string `json:"content_hash,omitempty"` // ses dosyasının sha256'sı (cache için)
+	CacheKey    string `json:"cache_key,omitempty"`
+	CacheHit    bool   `json:"cache_hit"` // sonuç cache'ten geldi (dönüştürme/yükleme/deşifre yapılmadı)
+
+	Error     string    `json:"error,omitempty"` // son denemenin hatası
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Done - iş tamamlanmış mı
+func (m *Manifest) Done() bool {
+	return m.Stage.Reached(StageExported)
+}
+
+// Chunk - parçalara bölünen bir sesin tek parçasının durumu
+//
+// Parçalar birbirinden bağımsız yüklenip tanınır; resume sonucu kaydedilmiş
+// parçaları atlar, işlemi başlatılmış parçaların işlemine yeniden bağlanır.
+type Chunk struct {
+	audio.Chunk
+
+	Sync          bool   `json:"sync"` // senkron tanıma (yükleme yok)
+	ObjectName    string `json:"object_name,omitempty"`
+	AudioURI      string `json:"audio_uri,omitempty"`
+	OperationName string `json:"operation_name,omitempty"`
+	ResultPath    string `json:"result_path,omitempty"`
+}
+
+// Done - parçanın sonucu kaydedilmiş mi
+func (c *Chunk) Done() bool {
+	return c.ResultPath != ""
+}
+
+// ChunksDone - tamamlanan parça sayısı
+func (m *Manifest) ChunksDone() int {
+	done := 0
+	for i := range m.Chunks {
+		if m.Chunks[i].Done() {
+			done++
+		}
+	}
+	return done
+}
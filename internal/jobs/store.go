@@ -0,0 +1,210 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"spt2/pkg/models"
+)
+
+// Store - manifest'lerin tutulduğu dizin (varsayılan: <output_dir>/jobs)
+//
+// Her iş için <id>.json manifest'i ve deşifre bittikten sonra
+// <id>.result.json sonucu yazılır. Parçalara bölünen seslerde her parçanın
+// sonucu ayrıca <id>.part-NNN.result.json olarak tutulur.
+type Store struct {
+	dir string
+}
+
+// yeni job store oluşturma (dizin ilk kayıtta oluşturulur)
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir - manifest dizini
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Create - ses dosyası için yeni iş kaydı açar
+//
+// ID, zaman ve dosya adından üretilir (20240610-150405-ders1); aynı saniyede
+// aynı adla açılan işler -2, -3 ... ekiyle ayrılır.
+func (s *Store) Create(audioFile string, outputDir string) (*Manifest, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("job dizini oluşturulamadı: %w", err)
+	}
+
+	now := time.Now()
+	baseName := filepath.Base(audioFile)
+	baseName = strings.TrimSuffix(baseName, filepath.Ext(baseName))
+	prefix := now.Format("20060102-150405") + "-" + baseName
+
+	for attempt := 1; ; attempt++ {
+		id := prefix
+		if attempt > 1 {
+			id = fmt.Sprintf("%s-%d", prefix, attempt)
+		}
+
+		// O_EXCL: paralel (batch) çalışmalarda aynı ID'nin iki kez alınmasını önler
+		file, err := os.OpenFile(s.manifestPath(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("job kaydı oluşturulamadı: %w", err)
+		}
+		file.Close()
+
+		manifest := &Manifest{
+			ID:        id,
+			AudioFile: audioFile,
+			OutputDir: outputDir,
+			Stage:     StageCreated,
+			CreatedAt: now,
+		}
+		if err := s.Save(manifest); err != nil {
+			return nil, err
+		}
+		return manifest, nil
+	}
+}
+
+// Save - manifest'i diske yazar (önce geçici dosyaya, sonra rename)
+func (s *Store) Save(manifest *Manifest) error {
+	manifest.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("job kaydı oluşturulamadı: %w", err)
+	}
+
+	return writeFileAtomic(s.manifestPath(manifest.ID), data)
+}
+
+// Load - ID ile manifest okuma
+func (s *Store) Load(id string) (*Manifest, error) {
+	if id == "" || id != filepath.Base(id) {
+		return nil, fmt.Errorf("geçersiz job ID: %q", id)
+	}
+
+	data, err := os.ReadFile(s.manifestPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("job kaydı okunamadı (%s): %w", id, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("job kaydı parse edilemedi (%s): %w", id, err)
+	}
+	return &manifest, nil
+}
+
+// List - tüm manifest'ler, oluşturulma sırasıyla
+func (s *Store) List() ([]*Manifest, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("job dizini okunamadı: %w", err)
+	}
+
+	var manifests []*Manifest
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".result.json") {
+			continue
+		}
+
+		manifest, err := s.Load(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].CreatedAt.Before(manifests[j].CreatedAt) })
+	return manifests, nil
+}
+
+// SaveResult - deşifre sonucunu işin yanına yazar ve ResultPath'i doldurur
+func (s *Store) SaveResult(manifest *Manifest, result *models.TranscriptionResult) error {
+	path := filepath.Join(s.dir, manifest.ID+".result.json")
+	if err := writeResult(path, result); err != nil {
+		return err
+	}
+	manifest.ResultPath = path
+	return nil
+}
+
+// LoadResult - daha önce kaydedilmiş deşifre sonucunu okuma
+func (s *Store) LoadResult(manifest *Manifest) (*models.TranscriptionResult, error) {
+	if manifest.ResultPath == "" {
+		return nil, fmt.Errorf("job kaydında deşifre sonucu yok: %s", manifest.ID)
+	}
+	return readResult(manifest.ResultPath)
+}
+
+// SaveChunkResult - parçanın (parçaya göreli zamanlı) sonucunu
+// <id>.part-001.result.json olarak yazar ve chunk.ResultPath'i doldurur
+func (s *Store) SaveChunkResult(manifest *Manifest, chunk *Chunk, result *models.TranscriptionResult) error {
+	path := filepath.Join(s.dir, fmt.Sprintf("%s.part-%03d.result.json", manifest.ID, chunk.Index+1))
+	if err := writeResult(path, result); err != nil {
+		return err
+	}
+	chunk.ResultPath = path
+	return nil
+}
+
+// LoadChunkResult - kaydedilmiş parça sonucunu okuma
+func (s *Store) LoadChunkResult(chunk *Chunk) (*models.TranscriptionResult, error) {
+	if chunk.ResultPath == "" {
+		return nil, fmt.Errorf("%d. parçanın deşifre sonucu yok", chunk.Index+1)
+	}
+	return readResult(chunk.ResultPath)
+}
+
+func writeResult(path string, result *models.TranscriptionResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("deşifre sonucu kaydedilemedi: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+func readResult(path string) (*models.TranscriptionResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("deşifre sonucu okunamadı: %w", err)
+	}
+
+	var result models.TranscriptionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("deşifre sonucu parse edilemedi: %w", err)
+	}
+	return &result, nil
+}
+
+func (s *Store) manifestPath(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// yarıda kalan yazmada eski kaydın bozulmaması için
+func writeFileAtomic(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("dosya yazılamadı: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("dosya yazılamadı: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+// Package keywords, keywords_file'dan yüklenen anahtar kelimeleri deşifre
+// sonucunun kelime listesinde arar.
+package keywords
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"spt2/internal/language"
+	"spt2/pkg/models"
+)
+
+// bulanık eşleşmenin denendiği en kısa kelime; daha kısa kelimelerde tek
+// harf farkı çoğu zaman başka bir kelimedir ("vize" / "bize")
+const minFuzzyLength = 5
+
+// Options - eşleştirme ayarları (config: keyword_*)
+type Options struct {
+	Fuzzy        bool // yazım farklarına izin ver (Levenshtein mesafesi)
+	MaxEdits     int  // bulanık eşleşmede kelime başına en fazla düzeltme
+	ContextWords int  // eşleşmenin önünde ve arkasında bağlama alınan kelime sayısı
+	Turkish      bool // Türkçe ekli halleri de eşleştir (sınav → sınavda, sınavları)
+}
+
+// OptionsFromConfig - config'teki keyword_* alanlarından ayarlar
+//
+// keyword_morphology "auto" ise Türkçe ek çözümlemesi language_code tr
+// olduğunda açılır (language_code "auto" ise sonucun tespit edilen dili
+// için cfg'nin bir kopyası verilir, bkz. analysis.Annotate).
+func OptionsFromConfig(cfg *models.AppConfig) Options {
+	options := Options{
+		Fuzzy:        cfg.KeywordFuzzy,
+		MaxEdits:     cfg.KeywordMaxEdits,
+		ContextWords: cfg.KeywordContextWords,
+	}
+	switch cfg.KeywordMorphology {
+	case "turkish":
+		options.Turkish = true
+	case "", "auto":
+		options.Turkish = language.IsTurkish(cfg.LanguageCode)
+	}
+	return options
+}
+
+// aranan anahtar kelime (birden fazla kelimeden oluşabilir)
+type term struct {
+	keyword string   // dosyadaki hali
+	tokens  []string // katlanmış (Fold) kelimeler
+	lowered []string // Türkçe küçük harfli kelimeler (ek çözümlemesi için)
+}
+
+// Matcher - anahtar kelime listesi üzerinde arama
+//
+// KULLANIM:
+//
+//	matcher := keywords.NewMatcher(cfg.Keywords, keywords.OptionsFromConfig(cfg))
+//	result.KeywordMatches = matcher.Match(result.Words)
+type Matcher struct {
+	terms   []term
+	options Options
+}
+
+// NewMatcher - anahtar kelimelerden eşleştirici oluşturma
+//
+// Boş satırlar ve katlanmış hali aynı olan tekrarlar atlanır.
+func NewMatcher(keywords []string, options Options) *Matcher {
+	if options.MaxEdits <= 0 {
+		options.MaxEdits = 1
+	}
+
+	matcher := &Matcher{options: options}
+	seen := make(map[string]bool)
+	for _, keyword := range keywords {
+		tokens := tokenize(keyword, Fold)
+		if len(tokens) == 0 {
+			continue
+		}
+		key := strings.Join(tokens, " ")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		matcher.terms = append(matcher.terms, term{
+			keyword: strings.TrimSpace(keyword),
+			tokens:  tokens,
+			lowered: tokenize(keyword, turkishNormalize),
+		})
+	}
+	return matcher
+}
+
+// Match - kelime listesindeki tüm eşleşmeler (zamana göre sıralı)
+//
+// KURALLAR:
+//   - büyük/küçük harf ve Türkçe karakterler katlanarak karşılaştırılır (ı/i, ş/s, ...)
+//   - kelimelere yapışık noktalama yok sayılır ("sınav," = "sınav")
+//   - çok kelimeli anahtar kelimeler ardışık kelimelerle eşleşir
+//   - Turkish açıksa son kelime ekli halleriyle de eşleşir ("final sınavı" →
+//     "final sınavında"), bkz. matchesTurkish
+//   - Fuzzy açıksa minFuzzyLength ve üzeri kelimelerde MaxEdits'e kadar
+//     harf farkına izin verilir
+//   - örtüşen terimlerden yalnızca en uzunu alınır ve arama eşleşmenin
+//     sonundan sürer ("proje planı" bulunduysa "proje" ayrıca raporlanmaz)
+func (matcher *Matcher) Match(words []models.WordInfo) []models.KeywordMatch {
+	if len(matcher.terms) == 0 || len(words) == 0 {
+		return nil
+	}
+
+	folded := make([]string, len(words))
+	lowered := make([]string, len(words))
+	for i, word := range words {
+		folded[i] = Fold(trimPunctuation(word.Word))
+		if matcher.options.Turkish {
+			lowered[i] = turkishNormalize(word.Word)
+		}
+	}
+
+	var matches []models.KeywordMatch
+	for i := 0; i < len(words); {
+		best, distance, ok := matcher.longestAt(folded, lowered, i)
+		if !ok {
+			i++
+			continue
+		}
+		end := i + len(best.tokens)
+		matches = append(matches, models.KeywordMatch{
+			Keyword:     best.keyword,
+			MatchedText: joinWords(words[i:end]),
+			Timestamp:   words[i].StartTime,
+			EndTime:     words[end-1].EndTime,
+			Context:     matcher.context(words, i, end),
+			SpeakerTag:  words[i].SpeakerTag,
+			Distance:    distance,
+		})
+		i = end
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].Timestamp < matches[b].Timestamp })
+	return matches
+}
+
+// start'ta eşleşen en uzun terim; eşit uzunlukta daha az düzeltme
+// gerektiren, o da eşitse listede önce gelen seçilir
+func (matcher *Matcher) longestAt(folded []string, lowered []string, start int) (term, int, bool) {
+	var best term
+	bestDistance, found := 0, false
+	for _, term := range matcher.terms {
+		distance, ok := matcher.matchAt(term, folded, lowered, start)
+		if !ok {
+			continue
+		}
+		if !found || len(term.tokens) > len(best.tokens) ||
+			(len(term.tokens) == len(best.tokens) && distance < bestDistance) {
+			best, bestDistance, found = term, distance, true
+		}
+	}
+	return best, bestDistance, found
+}
+
+// terim folded[start:] ile eşleşiyor mu; toplam düzeltme sayısıyla
+func (matcher *Matcher) matchAt(term term, folded []string, lowered []string, start int) (int, bool) {
+	if start+len(term.tokens) > len(folded) {
+		return 0, false
+	}
+
+	total := 0
+	for j, token := range term.tokens {
+		word := folded[start+j]
+		if word == token {
+			continue
+		}
+		if matcher.options.Turkish && j == len(term.tokens)-1 && matchesTurkish(lowered[start+j], term.lowered[j]) {
+			continue
+		}
+		if !matcher.options.Fuzzy || utf8.RuneCountInString(token) < minFuzzyLength {
+			return 0, false
+		}
+		distance := levenshtein(word, token, matcher.options.MaxEdits)
+		if distance > matcher.options.MaxEdits {
+			return 0, false
+		}
+		total += distance
+	}
+	return total, true
+}
+
+// eşleşmenin çevresindeki kelimeler
+func (matcher *Matcher) context(words []models.WordInfo, start int, end int) string {
+	from := start - matcher.options.ContextWords
+	if from < 0 {
+		from = 0
+	}
+	to := end + matcher.options.ContextWords
+	if to > len(words) {
+		to = len(words)
+	}
+	return joinWords(words[from:to])
+}
+
+// Fold - karşılaştırma için kelimeyi küçük harfe ve aksansız hale getirme
+//
+// "Işık" → "isik", "ÇÖĞÜŞ" → "cogus"
+func Fold(word string) string {
+	var folded strings.Builder
+	for _, r := range word {
+		if replacement, ok := foldMap[r]; ok {
+			folded.WriteRune(replacement)
+			continue
+		}
+		folded.WriteRune(unicode.ToLower(r))
+	}
+	return folded.String()
+}
+
+// Türkçe ve yaygın Latin aksanlı harflerin ASCII karşılıkları
+var foldMap = map[rune]rune{
+	'ı': 'i', 'I': 'i', 'İ': 'i', 'î': 'i', 'Î': 'i',
+	'ş': 's', 'Ş': 's',
+	'ç': 'c', 'Ç': 'c',
+	'ğ': 'g', 'Ğ': 'g',
+	'ö': 'o', 'Ö': 'o',
+	'ü': 'u', 'Ü': 'u', 'û': 'u', 'Û': 'u',
+	'â': 'a', 'Â': 'a',
+	'é': 'e', 'É': 'e', 'è': 'e', 'ê': 'e',
+	'á': 'a', 'à': 'a', 'ä': 'a',
+	'ó': 'o', 'ò': 'o',
+	'ú': 'u', 'ù': 'u',
+	'í': 'i', 'ì': 'i',
+	'ñ': 'n',
+}
+
+// anahtar kelimeyi normalize edilmiş kelimelere bölme
+func tokenize(keyword string, normalize func(string) string) []string {
+	var tokens []string
+	for _, field := range strings.Fields(keyword) {
+		if token := normalize(trimPunctuation(field)); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func trimPunctuation(word string) string {
+	return strings.TrimFunc(word, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+}
+
+func joinWords(words []models.WordInfo) string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.Word
+	}
+	return strings.Join(texts, " ")
+}
+
+// iki kelime arasındaki Levenshtein mesafesi (rune bazlı)
+//
+// Mesafe limit'i aşacağı kesinleşince limit+1 döner.
+func levenshtein(a string, b string, limit int) int {
+	source, target := []rune(a), []rune(b)
+	if diff := len(source) - len(target); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if deletion := previous[j] + 1; deletion < current[j] {
+				current[j] = deletion
+			}
+			if insertion := current[j-1] + 1; insertion < current[j] {
+				current[j] = insertion
+			}
+			if current[j] < rowMin {
+				rowMin = current[j]
+			}
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
@@ -0,0 +1,137 @@
+package keywords
+
+import (
+	"strings"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+// her kelimeye bir saniye, konuşmacı 1
+func testWords(text string) []models.WordInfo {
+	var words []models.WordInfo
+	for i, word := range strings.Fields(text) {
+		words = append(words, models.WordInfo{
+			Word:       word,
+			StartTime:  float64(i),
+			EndTime:    float64(i) + 0.9,
+			SpeakerTag: 1,
+		})
+	}
+	return words
+}
+
+func matchedTexts(matches []models.KeywordMatch) []string {
+	texts := make([]string, len(matches))
+	for i, match := range matches {
+		texts[i] = match.Keyword + "=" + match.MatchedText
+	}
+	return texts
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		keywords []string
+		options  Options
+		text     string
+		want     []string
+	}{
+		{
+			name:     "büyük küçük harf ve noktalama",
+			keywords: []string{"API"},
+			text:     "bu api, REST ile Api.",
+			want:     []string{"API=api,", "API=Api."},
+		},
+		{
+			name:     "İ/ı ve Türkçe karakter katlama",
+			keywords: []string{"Işık", "İstanbul"},
+			text:     "ISIK istanbul'da değil ISTANBUL",
+			want:     []string{"Işık=ISIK", "İstanbul=ISTANBUL"},
+		},
+		{
+			name:     "Latin aksanları",
+			keywords: []string{"cafe"},
+			text:     "Café açık",
+			want:     []string{"cafe=Café"},
+		},
+		{
+			name:     "çok kelimeli terim",
+			keywords: []string{"final sınavı"},
+			text:     "final haftası final sınavı yapılacak",
+			want:     []string{"final sınavı=final sınavı"},
+		},
+		{
+			name:     "örtüşen terimlerden en uzunu",
+			keywords: []string{"proje", "proje planı", "planı"},
+			text:     "proje planı hazır proje bitti",
+			want:     []string{"proje planı=proje planı", "proje=proje"},
+		},
+		{
+			name:     "fuzzy minFuzzyLength'te",
+			keywords: []string{"sınav"},
+			options:  Options{Fuzzy: true},
+			text:     "sinaf sınavv sınaaav",
+			want:     []string{"sınav=sinaf", "sınav=sınavv"},
+		},
+		{
+			name:     "fuzzy minFuzzyLength altında kapalı",
+			keywords: []string{"vize"},
+			options:  Options{Fuzzy: true},
+			text:     "bize vize",
+			want:     []string{"vize=vize"},
+		},
+		{
+			name:     "fuzzy kapalıyken yazım farkı",
+			keywords: []string{"sınav"},
+			text:     "sinaf",
+			want:     nil,
+		},
+		{
+			name:     "Türkçe ekler çok kelimelinin son kelimesinde",
+			keywords: []string{"final sınavı"},
+			options:  Options{Turkish: true},
+			text:     "finalde sınavı final sınavında",
+			want:     []string{"final sınavı=final sınavında"},
+		},
+	}
+	for _, test := range tests {
+		matches := NewMatcher(test.keywords, test.options).Match(testWords(test.text))
+		got := matchedTexts(matches)
+		if strings.Join(got, "|") != strings.Join(test.want, "|") {
+			t.Errorf("%s: eşleşmeler %q, beklenen %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestMatchDetails(t *testing.T) {
+	matcher := NewMatcher([]string{"sınav", "Sınav", " "}, Options{Fuzzy: true, ContextWords: 1})
+	words := testWords("yarın sinaf var")
+	words[1].SpeakerTag = 2
+
+	matches := matcher.Match(words)
+	if len(matches) != 1 {
+		t.Fatalf("%d eşleşme, beklenen 1 (tekrar eden terim atlanmalı)", len(matches))
+	}
+	match := matches[0]
+	if match.Timestamp != 1 || match.EndTime != 1.9 || match.SpeakerTag != 2 || match.Distance != 1 {
+		t.Errorf("eşleşme = %+v", match)
+	}
+	if match.Context != "yarın sinaf var" {
+		t.Errorf("bağlam = %q", match.Context)
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := map[string]string{
+		"Işık":  "isik",
+		"ÇÖĞÜŞ": "cogus",
+		"İzmir": "izmir",
+		"Kâğıt": "kagit",
+	}
+	for word, want := range tests {
+		if got := Fold(word); got != want {
+			t.Errorf("Fold(%q) = %q, beklenen %q", word, got, want)
+		}
+	}
+}
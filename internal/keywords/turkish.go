@@ -0,0 +1,258 @@
+package keywords
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// bir kelimede aranan en fazla ek sayısı ("kitap-lar-ımız-da-ydı" = 4)
+const maxSuffixes = 4
+
+// Türkçe çekim ve yapım ekleri (büyük ünlü uyumu için arşifonemlerle)
+//
+//	A: a/e   I: ı/i/u/ü   D: d/t   C: c/ç
+//	(y), (n), (s): önceki harf ünlüyse gelen kaynaştırma harfi
+//	(I): önceki harf ünsüzse gelen yardımcı ünlü
+var turkishSuffixes = []string{
+	// çoğul
+	"lAr",
+	// iyelik
+	"(I)m", "(I)n", "(s)I", "(I)mIz", "(I)nIz",
+	// hal ekleri (3. tekil iyelikten sonra n kaynaştırmasıyla)
+	"(y)I", "(y)A", "DA", "DAn", "(n)In", "(y)lA", "nI", "nA", "nDA", "nDAn",
+	// yapım ekleri
+	"lI", "sIz", "lIk", "CI", "CA", "ki",
+	// ek-fiil
+	"DIr", "(y)DI", "(y)sA", "(y)mIş", "(y)Im", "sIn", "(y)Iz",
+}
+
+// TurkishLower - Türkçe kurallarıyla küçük harf (I → ı, İ → i)
+func TurkishLower(word string) string {
+	return strings.ToLowerSpecial(unicode.TurkishCase, word)
+}
+
+// karşılaştırma öncesi kelimeyi Türkçe küçük harfe çevirip özel isim
+// kesme işaretlerini kaldırma ("Ankara'da" → "ankarada")
+func turkishNormalize(word string) string {
+	word = strings.NewReplacer("'", "", "’", "").Replace(trimPunctuation(word))
+	return TurkishLower(word)
+}
+
+// matchesTurkish - kelime, kök + geçerli ek zinciri mi
+//
+// KURALLAR:
+//   - ekler büyük ünlü uyumuna göre çekilir; kökten sonraki ilk ek, alıntı
+//     kelimeler için ince ünlülü de olabilir (final → finali, saat → saati)
+//   - sert ünsüzle biten kök ve ekler ünlüyle başlayan ekten önce
+//     yumuşayabilir (kitap → kitabı, renk → rengi, sınıflık → sınıflığı)
+//   - D ve C, önceki harf sert ünsüzse t ve ç olur (kitapta, işçi)
+//   - kök + tek ünsüz kabul edilmez: ünlüyle biten kökte (I)m/(I)n tek
+//     harfe iner ve başka kelimelerle karışır (soru → sorun, ara → aram)
+func matchesTurkish(word string, stem string) bool {
+	if stem == "" {
+		return false
+	}
+	for _, form := range withSoftening(stem) {
+		if !strings.HasPrefix(word, form) {
+			continue
+		}
+		rest := word[len(form):]
+		if form != stem && !startsWithVowel(rest) {
+			continue
+		}
+		if utf8.RuneCountInString(rest) == 1 && !startsWithVowel(rest) {
+			continue
+		}
+		if suffixChain(rest, form, true, 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// kalan kısım ek zinciriyle tamamen tüketilebiliyor mu
+func suffixChain(rest string, previous string, first bool, depth int) bool {
+	if rest == "" {
+		return true
+	}
+	if depth >= maxSuffixes {
+		return false
+	}
+
+	for _, template := range turkishSuffixes {
+		for _, vowel := range harmonyVowels(previous, first) {
+			surface := realizeSuffix(template, previous, vowel)
+			for _, form := range withSoftening(surface) {
+				if !strings.HasPrefix(rest, form) {
+					continue
+				}
+				next := rest[len(form):]
+				if form != surface && !startsWithVowel(next) {
+					continue
+				}
+				if suffixChain(next, previous+form, false, depth+1) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ekin ünlüsünü belirleyen son ünlü; ilk ekte ince karşılığı da denenir
+func harmonyVowels(previous string, first bool) []rune {
+	vowel := lastVowel(previous)
+	if !first {
+		return []rune{vowel}
+	}
+	if front := frontVowel(vowel); front != vowel {
+		return []rune{vowel, front}
+	}
+	return []rune{vowel}
+}
+
+// ek şablonunu önceki metne göre çekme ("lArI" + "sınav" → "ları")
+func realizeSuffix(template string, previous string, vowel rune) string {
+	var surface []rune
+	last := lastRune(previous)
+	if !strings.ContainsAny(previous, "aeıioöuü") {
+		last = 'e' // ünlüsüz kısaltmalar harf adıyla okunur (TRT'de, TRT'yi)
+	}
+	runes := []rune(template)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		// isteğe bağlı harf: (y), (n), (s) ünlüden, (I) ünsüzden sonra
+		if r == '(' && i+2 < len(runes) && runes[i+2] == ')' {
+			buffer := runes[i+1]
+			i += 2
+			if buffer == 'I' {
+				if isVowel(last) {
+					continue
+				}
+			} else if !isVowel(last) {
+				continue
+			}
+			r = buffer
+		}
+
+		switch r {
+		case 'A':
+			r = harmonyA(vowel)
+		case 'I':
+			r = harmonyI(vowel)
+		case 'D':
+			r = 'd'
+			if isVoiceless(last) {
+				r = 't'
+			}
+		case 'C':
+			r = 'c'
+			if isVoiceless(last) {
+				r = 'ç'
+			}
+		}
+
+		surface = append(surface, r)
+		last = r
+		if isVowel(r) {
+			vowel = r
+		}
+	}
+	return string(surface)
+}
+
+// sert ünsüzle biten metnin yumuşamış hali de (kitap → kitab, renk → reng)
+func withSoftening(text string) []string {
+	runes := []rune(text)
+	if len(runes) < 2 {
+		return []string{text}
+	}
+
+	var soft rune
+	switch runes[len(runes)-1] {
+	case 'p':
+		soft = 'b'
+	case 'ç':
+		soft = 'c'
+	case 't':
+		soft = 'd'
+	case 'k':
+		soft = 'ğ'
+		if runes[len(runes)-2] == 'n' {
+			soft = 'g'
+		}
+	default:
+		return []string{text}
+	}
+	return []string{text, string(runes[:len(runes)-1]) + string(soft)}
+}
+
+func lastVowel(text string) rune {
+	runes := []rune(text)
+	for i := len(runes) - 1; i >= 0; i-- {
+		if isVowel(runes[i]) {
+			return runes[i]
+		}
+	}
+	return 'e' // ünlüsüz kısaltmalar ince okunur (TRT'de)
+}
+
+func lastRune(text string) rune {
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return 0
+	}
+	return runes[len(runes)-1]
+}
+
+func startsWithVowel(text string) bool {
+	for _, r := range text {
+		return isVowel(r)
+	}
+	return false
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeıioöuü", r)
+}
+
+func isVoiceless(r rune) bool {
+	return strings.ContainsRune("çfhkpsşt", r)
+}
+
+// kalın ünlünün ince karşılığı (alıntı kelimelerdeki ince ekler için)
+func frontVowel(vowel rune) rune {
+	switch vowel {
+	case 'a':
+		return 'e'
+	case 'ı':
+		return 'i'
+	case 'o':
+		return 'ö'
+	case 'u':
+		return 'ü'
+	}
+	return vowel
+}
+
+func harmonyA(vowel rune) rune {
+	if strings.ContainsRune("aıou", vowel) {
+		return 'a'
+	}
+	return 'e'
+}
+
+func harmonyI(vowel rune) rune {
+	switch vowel {
+	case 'a', 'ı':
+		return 'ı'
+	case 'o', 'u':
+		return 'u'
+	case 'ö', 'ü':
+		return 'ü'
+	}
+	return 'i'
+}
@@ -0,0 +1,65 @@
+package keywords
+
+import "testing"
+
+func TestMatchesTurkish(t *testing.T) {
+	tests := []struct {
+		word string
+		stem string
+		want bool
+	}{
+		// ünlü uyumu ve ek zinciri
+		{"sınavlarımızdan", "sınav", true},
+		{"evlerimizde", "ev", true},
+		{"sorular", "soru", true},
+		{"sorunun", "soru", true},
+		{"kitaplarımızdaydı", "kitap", true},
+		// ünsüz yumuşaması
+		{"kitabımızdan", "kitap", true},
+		{"rengi", "renk", true},
+		{"ağacı", "ağaç", true},
+		// sert ünsüz benzeşmesi
+		{"kitapta", "kitap", true},
+		{"işçi", "iş", true},
+		// alıntı kelimelerde ince ek
+		{"finali", "final", true},
+		{"saati", "saat", true},
+		// özel isim kesmesi ve ünlüsüz kısaltma
+		{turkishNormalize("Ankara'da"), "ankara", true},
+		{turkishNormalize("TRT'yi"), "trt", true},
+		{"sınav", "sınav", true},
+
+		// kök + tek ünsüz başka kelime olabilir
+		{"sorun", "soru", false},
+		{"aram", "ara", false},
+		// yumuşamış kök ünlüsüz devam edemez
+		{"kitab", "kitap", false},
+		// ilk ekten sonra ünlü uyumu zorunlu
+		{"sınavlarımızden", "sınav", false},
+		// ek olmayan devam
+		{"sınavx", "sınav", false},
+		{"kitapçık", "kitap", false},
+		// maxSuffixes'ten uzun zincir
+		{"sınavlarlarlarlarlar", "sınav", false},
+		{"", "sınav", false},
+		{"sınav", "", false},
+	}
+	for _, test := range tests {
+		if got := matchesTurkish(test.word, test.stem); got != test.want {
+			t.Errorf("matchesTurkish(%q, %q) = %t, beklenen %t", test.word, test.stem, got, test.want)
+		}
+	}
+}
+
+func TestTurkishNormalize(t *testing.T) {
+	tests := map[string]string{
+		"İSTANBUL'da": "istanbulda",
+		"IŞIK":        "ışık",
+		"Ankara’nın,": "ankaranın",
+	}
+	for word, want := range tests {
+		if got := turkishNormalize(word); got != want {
+			t.Errorf("turkishNormalize(%q) = %q, beklenen %q", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package language, tanıma dillerinin (language_code, alternative_language_codes)
+// ve dil profillerinin (language_profiles) çözümlenmesini toplar.
+package language
+
+import (
+	"strings"
+
+	"spt2/pkg/models"
+)
+
+// Auto - language_code'un otomatik dil tespiti değeri
+const Auto = "auto"
+
+// RecognitionLanguages - API'a gönderilecek ana dil ve alternatifler
+//
+// "auto" modunda alternative_language_codes'un ilki ana dil, kalanı
+// alternatif olur; API her sonuç için bu diller arasından tespit yapar.
+func RecognitionLanguages(cfg *models.AppConfig) (string, []string) {
+	if cfg.LanguageCode != Auto {
+		return cfg.LanguageCode, cfg.AlternativeLanguageCodes
+	}
+	if len(cfg.AlternativeLanguageCodes) == 0 {
+		return "", nil
+	}
+	return cfg.AlternativeLanguageCodes[0], cfg.AlternativeLanguageCodes[1:]
+}
+
+// Candidates - sonuçlarda görülebilecek tüm diller (ana dil ilk sırada)
+func Candidates(cfg *models.AppConfig) []string {
+	primary, alternatives := RecognitionLanguages(cfg)
+	return append([]string{primary}, alternatives...)
+}
+
+// Normalize - API'ın döndürdüğü kodu ("tr-tr") config'teki yazımına ("tr-TR")
+// çevirme; adaylarda yoksa olduğu gibi döner
+func Normalize(code string, candidates []string) string {
+	for _, candidate := range candidates {
+		if strings.EqualFold(code, candidate) {
+			return candidate
+		}
+	}
+	return code
+}
+
+// Dominant - segmentlerde en uzun süre konuşulan dil (dil bilgisi yoksa "")
+func Dominant(segments []models.Segment) string {
+	durations := make(map[string]float64)
+	var order []string
+	for _, segment := range segments {
+		if segment.LanguageCode == "" {
+			continue
+		}
+		if _, ok := durations[segment.LanguageCode]; !ok {
+			order = append(order, segment.LanguageCode)
+		}
+		durations[segment.LanguageCode] += segment.End - segment.Start
+	}
+
+	dominant := ""
+	for _, code := range order {
+		if dominant == "" || durations[code] > durations[dominant] {
+			dominant = code
+		}
+	}
+	return dominant
+}
+
+// Profile - dile ait profil (speech contexts ve keywords dosyaları)
+func Profile(cfg *models.AppConfig, code string) (models.LanguageProfile, bool) {
+	for name, profile := range cfg.LanguageProfiles {
+		if strings.EqualFold(name, code) {
+			return profile, true
+		}
+	}
+	return models.LanguageProfile{}, false
+}
+
+// IsTurkish - dil kodu Türkçe mi ("tr", "tr-TR")
+func IsTurkish(code string) bool {
+	code = strings.ToLower(code)
+	return code == "tr" || strings.HasPrefix(code, "tr-")
+}
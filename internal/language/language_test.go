@@ -0,0 +1,91 @@
+package language
+
+import (
+	"strings"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+func TestRecognitionLanguages(t *testing.T) {
+	tests := []struct {
+		code         string
+		alternatives []string
+		primary      string
+		rest         []string
+	}{
+		{"tr-TR", nil, "tr-TR", nil},
+		{"tr-TR", []string{"en-US"}, "tr-TR", []string{"en-US"}},
+		{Auto, []string{"tr-TR", "en-US", "de-DE"}, "tr-TR", []string{"en-US", "de-DE"}},
+		{Auto, nil, "", nil},
+	}
+	for _, test := range tests {
+		cfg := &models.AppConfig{LanguageCode: test.code, AlternativeLanguageCodes: test.alternatives}
+		primary, rest := RecognitionLanguages(cfg)
+		if primary != test.primary || strings.Join(rest, ",") != strings.Join(test.rest, ",") {
+			t.Errorf("%s %v: %s %v, beklenen %s %v", test.code, test.alternatives, primary, rest, test.primary, test.rest)
+		}
+	}
+
+	cfg := &models.AppConfig{LanguageCode: Auto, AlternativeLanguageCodes: []string{"tr-TR", "en-US"}}
+	if got := strings.Join(Candidates(cfg), ","); got != "tr-TR,en-US" {
+		t.Errorf("adaylar = %s", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	candidates := []string{"tr-TR", "en-US"}
+	tests := map[string]string{
+		"tr-tr": "tr-TR",
+		"EN-us": "en-US",
+		"de-de": "de-de", // adaylarda yok
+		"":      "",
+	}
+	for code, want := range tests {
+		if got := Normalize(code, candidates); got != want {
+			t.Errorf("Normalize(%q) = %q, beklenen %q", code, got, want)
+		}
+	}
+}
+
+func TestDominant(t *testing.T) {
+	segment := func(code string, start float64, end float64) models.Segment {
+		return models.Segment{LanguageCode: code, Start: start, End: end}
+	}
+	tests := []struct {
+		name     string
+		segments []models.Segment
+		want     string
+	}{
+		{"boş", nil, ""},
+		{"dil bilgisi yok", []models.Segment{segment("", 0, 10)}, ""},
+		{"toplam süre", []models.Segment{segment("en-US", 0, 10), segment("tr-TR", 10, 16), segment("tr-TR", 16, 22)}, "tr-TR"},
+		{"eşitlikte ilk görülen", []models.Segment{segment("en-US", 0, 5), segment("tr-TR", 5, 10)}, "en-US"},
+	}
+	for _, test := range tests {
+		if got := Dominant(test.segments); got != test.want {
+			t.Errorf("%s: %q, beklenen %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestProfile(t *testing.T) {
+	cfg := &models.AppConfig{LanguageProfiles: map[string]models.LanguageProfile{
+		"tr-tr": {Keywords: []string{"sınav"}},
+	}}
+	if profile, ok := Profile(cfg, "tr-TR"); !ok || len(profile.Keywords) != 1 {
+		t.Errorf("tr-TR profili bulunamadı: %+v", profile)
+	}
+	if _, ok := Profile(cfg, "en-US"); ok {
+		t.Error("en-US profili olmamalı")
+	}
+}
+
+func TestIsTurkish(t *testing.T) {
+	tests := map[string]bool{"tr": true, "tr-TR": true, "TR-tr": true, "en-US": false, "tra": false, "": false}
+	for code, want := range tests {
+		if got := IsTurkish(code); got != want {
+			t.Errorf("IsTurkish(%q) = %t", code, got)
+		}
+	}
+}
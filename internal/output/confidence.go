@@ -0,0 +1,201 @@
+package output
+
+import (
+	"strings"
+	"unicode"
+
+	"spt2/internal/analysis"
+	"spt2/pkg/models"
+)
+
+// düşük güvenli kelimelerin bir çıktı formatında ele alınışı (config: low_confidence)
+const (
+	LowConfidenceKeep   = "keep"   // olduğu gibi yaz
+	LowConfidenceFlag   = "flag"   // metin formatlarında kelimeden sonra lowConfidenceMarker
+	LowConfidenceFilter = "filter" // çıktıdan çıkar
+)
+
+// flag modunda düşük güvenli kelimeye eklenen işaret ("kelime(?)", "sonu(?).")
+const lowConfidenceMarker = "(?)"
+
+// LowConfidenceMode - formatın düşük güven modu (belirtilmemişse keep)
+func LowConfidenceMode(cfg *models.AppConfig, formatName string) string {
+	if mode, ok := cfg.LowConfidence[formatName]; ok && mode != "" {
+		return mode
+	}
+	return LowConfidenceKeep
+}
+
+// ApplyLowConfidence - WordInfo.LowConfidence işaretli kelimeleri moda göre
+// işlenmiş bir kopya
+//
+// KURALLAR:
+//   - keep: sonuç değiştirilmeden döner
+//   - flag: markText ise kelimelere (noktalamadan önce) lowConfidenceMarker eklenir;
+//     değilse (JSON) low_confidence alanı yeterlidir, sonuç değişmez
+//   - filter: kelimeler Words'ten, segment metinlerinden ve konuşmacı
+//     metinlerinden çıkarılır; filtrelenen kelimeye denk gelen anahtar kelime
+//     eşleşmeleri atılır, kalanların bağlamı filtrelenmiş kelimelerden kurulur
+//   - segment alternatifleri API'nin ayrı tahminleri olduğundan değişmez
+//
+// Kelime değiştiyse Transcript kelimelerden, Speakers (adları korunarak)
+// analysis.Speakers ile yeniden oluşturulur. Orijinal sonuç değiştirilmez
+// (aynı sonuç birden fazla formata yazılır).
+func ApplyLowConfidence(result *models.TranscriptionResult, mode string, markText bool) *models.TranscriptionResult {
+	if mode == LowConfidenceKeep || (mode == LowConfidenceFlag && !markText) {
+		return result
+	}
+
+	changed := false
+	words := make([]models.WordInfo, 0, len(result.Words))
+	var filtered []models.WordInfo
+	for _, word := range result.Words {
+		if !word.LowConfidence {
+			words = append(words, word)
+			continue
+		}
+
+		changed = true
+		if mode == LowConfidenceFlag {
+			core := strings.TrimRightFunc(word.Word, unicode.IsPunct)
+			word.Word = core + lowConfidenceMarker + word.Word[len(core):]
+			words = append(words, word)
+		} else {
+			filtered = append(filtered, word)
+		}
+	}
+	if !changed {
+		return result
+	}
+
+	processed := *result
+	processed.Words = words
+	processed.Transcript = joinWordTexts(words)
+	if len(result.Speakers) > 0 {
+		processed.Speakers = rebuildSpeakers(result.Speakers, words)
+	}
+	if len(filtered) > 0 {
+		processed.Segments = filterSegments(result.Segments, filtered)
+		processed.KeywordMatches = filterKeywordMatches(result.KeywordMatches, result.Words, words)
+	}
+
+	return &processed
+}
+
+// konuşmacı istatistiklerini işlenmiş kelimelerden yeniden hesaplama;
+// kanal adları (channel_names) eski listeden taşınır
+func rebuildSpeakers(previous []models.SpeakerInfo, words []models.WordInfo) []models.SpeakerInfo {
+	names := make(map[int32]string)
+	for _, speaker := range previous {
+		names[speaker.SpeakerTag] = speaker.Name
+	}
+	speakers := analysis.Speakers(words)
+	for i := range speakers {
+		speakers[i].Name = names[speakers[i].SpeakerTag]
+	}
+	return speakers
+}
+
+// segment metinlerinden, segmentin zaman aralığına (ve çok kanallıysa
+// kanalına) düşen filtrelenmiş kelimeleri sırayla çıkarma
+func filterSegments(segments []models.Segment, filtered []models.WordInfo) []models.Segment {
+	if len(segments) == 0 {
+		return segments
+	}
+
+	processed := make([]models.Segment, len(segments))
+	used := make([]bool, len(filtered))
+	for i, segment := range segments {
+		tokens := strings.Fields(segment.Transcript)
+		cursor := 0
+		for j, word := range filtered {
+			if used[j] || word.StartTime < segment.Start || word.StartTime > segment.End ||
+				(segment.ChannelTag != 0 && word.ChannelTag != segment.ChannelTag) {
+				continue
+			}
+			for k := cursor; k < len(tokens); k++ {
+				if tokens[k] == word.Word {
+					tokens = append(tokens[:k], tokens[k+1:]...)
+					cursor = k
+					used[j] = true
+					break
+				}
+			}
+		}
+		segment.Transcript = strings.Join(tokens, " ")
+		processed[i] = segment
+	}
+	return processed
+}
+
+// filtrelenmiş kelime içeren eşleşmeleri atma, kalanların bağlamını
+// (aynı sayıda kelimeyle) filtrelenmiş kelimelerden kurma
+func filterKeywordMatches(matches []models.KeywordMatch, original []models.WordInfo, kept []models.WordInfo) []models.KeywordMatch {
+	if len(matches) == 0 {
+		return matches
+	}
+
+	// eşleşmenin başladığı kelime, kept içindeki sırasıyla
+	keptIndex := make(map[float64]int, len(kept))
+	for i := len(kept) - 1; i >= 0; i-- {
+		keptIndex[kept[i].StartTime] = i
+	}
+
+	var processed []models.KeywordMatch
+	for _, match := range matches {
+		start, ok := keptIndex[match.Timestamp]
+		length := len(strings.Fields(match.MatchedText))
+		if !ok || start+length > len(kept) || joinWordTexts(kept[start:start+length]) != match.MatchedText {
+			continue
+		}
+
+		before, after := contextSize(match, original, length)
+		from := start - before
+		if from < 0 {
+			from = 0
+		}
+		to := start + length + after
+		if to > len(kept) {
+			to = len(kept)
+		}
+		match.Context = joinWordTexts(kept[from:to])
+		processed = append(processed, match)
+	}
+	return processed
+}
+
+// eşleşmenin orijinal bağlamında önünde ve arkasında kaç kelime vardı
+func contextSize(match models.KeywordMatch, words []models.WordInfo, length int) (int, int) {
+	contextLength := len(strings.Fields(match.Context))
+	for start, word := range words {
+		if word.StartTime != match.Timestamp || start+length > len(words) {
+			continue
+		}
+		for from := start; from >= 0 && start-from+length <= contextLength; from-- {
+			to := from + contextLength
+			if to <= len(words) && to >= start+length && joinWordTexts(words[from:to]) == match.Context {
+				return start - from, to - start - length
+			}
+		}
+	}
+	return 0, 0
+}
+
+func joinWordTexts(words []models.WordInfo) string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.Word
+	}
+	return strings.Join(texts, " ")
+}
+
+// düşük güvenli kelime sayısı
+func lowConfidenceCount(words []models.WordInfo) int {
+	count := 0
+	for _, word := range words {
+		if word.LowConfidence {
+			count++
+		}
+	}
+	return count
+}
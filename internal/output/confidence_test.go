@@ -0,0 +1,111 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+// "final sınavı yarın(düşük) saat onda." iki konuşmacı, iki segment
+func lowConfidenceResult() *models.TranscriptionResult {
+	words := []models.WordInfo{
+		{Word: "Final", StartTime: 0, EndTime: 0.5, SpeakerTag: 1},
+		{Word: "sınavı", StartTime: 0.5, EndTime: 1, SpeakerTag: 1},
+		{Word: "yarın,", StartTime: 1, EndTime: 1.5, SpeakerTag: 1, LowConfidence: true},
+		{Word: "saat", StartTime: 2, EndTime: 2.5, SpeakerTag: 2},
+		{Word: "onda.", StartTime: 2.5, EndTime: 3, SpeakerTag: 2},
+	}
+	return &models.TranscriptionResult{
+		Transcript: "Final sınavı yarın, saat onda.",
+		Words:      words,
+		Segments: []models.Segment{
+			{Transcript: "Final sınavı yarın,", Start: 0, End: 1.5,
+				Alternatives: []models.Alternative{{Transcript: "Final sınavı yarın"}}},
+			{Transcript: "saat onda.", Start: 1.5, End: 3},
+		},
+		Speakers: []models.SpeakerInfo{
+			{SpeakerTag: 1, Name: "Hoca", Transcript: "Final sınavı yarın,"},
+			{SpeakerTag: 2, Name: "Öğrenci", Transcript: "saat onda."},
+		},
+		KeywordMatches: []models.KeywordMatch{
+			{Keyword: "final sınavı", MatchedText: "Final sınavı", Timestamp: 0, EndTime: 1, Context: "Final sınavı yarın, saat"},
+			{Keyword: "yarın", MatchedText: "yarın,", Timestamp: 1, EndTime: 1.5, Context: "sınavı yarın, saat"},
+			{Keyword: "saat", MatchedText: "saat", Timestamp: 2, EndTime: 2.5, Context: "yarın, saat onda."},
+		},
+	}
+}
+
+func TestApplyLowConfidenceKeep(t *testing.T) {
+	result := lowConfidenceResult()
+	if got := ApplyLowConfidence(result, LowConfidenceKeep, true); got != result {
+		t.Error("keep modu sonucu değiştirmemeli")
+	}
+	if got := ApplyLowConfidence(result, LowConfidenceFlag, false); got != result {
+		t.Error("JSON'da flag modu sonucu değiştirmemeli")
+	}
+
+	clean := lowConfidenceResult()
+	clean.Words[2].LowConfidence = false
+	if got := ApplyLowConfidence(clean, LowConfidenceFilter, true); got != clean {
+		t.Error("düşük güvenli kelime yokken sonuç değişmemeli")
+	}
+}
+
+func TestApplyLowConfidenceFlag(t *testing.T) {
+	result := lowConfidenceResult()
+	flagged := ApplyLowConfidence(result, LowConfidenceFlag, true)
+
+	if want := "Final sınavı yarın(?), saat onda."; flagged.Transcript != want {
+		t.Errorf("transcript = %q, beklenen %q", flagged.Transcript, want)
+	}
+	if flagged.Words[2].Word != "yarın(?)," {
+		t.Errorf("işaretli kelime = %q", flagged.Words[2].Word)
+	}
+	if flagged.Speakers[0].Transcript != "Final sınavı yarın(?)," || flagged.Speakers[0].Name != "Hoca" {
+		t.Errorf("konuşmacı = %+v", flagged.Speakers[0])
+	}
+	if len(flagged.KeywordMatches) != 3 {
+		t.Errorf("flag modunda eşleşmeler korunmalı: %d", len(flagged.KeywordMatches))
+	}
+	if result.Words[2].Word != "yarın," || result.Speakers[0].Transcript != "Final sınavı yarın," {
+		t.Error("orijinal sonuç değişti")
+	}
+}
+
+func TestApplyLowConfidenceFilter(t *testing.T) {
+	result := lowConfidenceResult()
+	filtered := ApplyLowConfidence(result, LowConfidenceFilter, true)
+
+	if want := "Final sınavı saat onda."; filtered.Transcript != want {
+		t.Errorf("transcript = %q, beklenen %q", filtered.Transcript, want)
+	}
+	if len(filtered.Words) != 4 {
+		t.Errorf("%d kelime, beklenen 4", len(filtered.Words))
+	}
+
+	if filtered.Segments[0].Transcript != "Final sınavı" || filtered.Segments[1].Transcript != "saat onda." {
+		t.Errorf("segmentler = %q, %q", filtered.Segments[0].Transcript, filtered.Segments[1].Transcript)
+	}
+	if filtered.Segments[0].Alternatives[0].Transcript != "Final sınavı yarın" {
+		t.Error("alternatifler değişmemeli")
+	}
+
+	speaker := filtered.Speakers[0]
+	if speaker.Transcript != "Final sınavı" || speaker.WordCount != 2 || speaker.Name != "Hoca" {
+		t.Errorf("konuşmacı = %+v", speaker)
+	}
+
+	var texts []string
+	for _, match := range filtered.KeywordMatches {
+		texts = append(texts, match.Keyword+": "+match.Context)
+	}
+	want := []string{"final sınavı: Final sınavı saat onda.", "saat: sınavı saat onda."}
+	if strings.Join(texts, "|") != strings.Join(want, "|") {
+		t.Errorf("eşleşmeler = %q, beklenen %q", texts, want)
+	}
+
+	if len(result.Words) != 5 || result.Segments[0].Transcript != "Final sınavı yarın," || len(result.KeywordMatches) != 3 {
+		t.Error("orijinal sonuç değişti")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"spt2/pkg/models"
@@ -16,6 +17,9 @@ type JSONOutput struct {
 	Metadata 			OutputMetadata				`json:"metadata"` 
 }
 
+//JSON çıktı formatının sürümü; major sürüm değişirse eski dosyalar ImportJSON ile okunamaz
+const FormatVersion = "1.2.0"
+
 //çıktı dosyası hakkında metadata bilgileri
 type OutputMetadata struct {
 	GeneratedAt	 string `json:"generated_at"`
@@ -34,7 +38,7 @@ func ExportJSON(result *models.TranscriptionResult, audioFilePath string, output
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 		AudioFile:	 filepath.Base(audioFilePath),
 		OutputFormat: "json",
-		Version: 	  "1.0.0",
+		Version: 	  FormatVersion,
 	}
 
 	jsonOutput := JSONOutput{
@@ -47,13 +51,42 @@ func ExportJSON(result *models.TranscriptionResult, audioFilePath string, output
 		return "", fmt.Errorf("JSON oluşturulamadı: %w", err)
 	}
 
-	audioFileName := filepath.Base(audioFilePath)
-	audioFileNameWithoutExt := audioFileName[:len(audioFileName)-len(filepath.Ext(audioFileName))]
-	outputFileName := audioFileNameWithoutExt + ".json"
-	outputPath := filepath.Join(outputDir, outputFileName)
+	outputPath := OutputPath(audioFilePath, outputDir, "json")
 
 	if err := os.WriteFile(outputPath, jsonData, 0644); err != nil {
 		return "", fmt.Errorf("JSON dosyası yazılamadı: %w", err)
 	}
 	return outputPath, nil
-}
\ No newline at end of file
+}
+
+//ExportJSON ile yazılmış dosyayı geri okuma
+//
+//Version'ın major kısmı FormatVersion ile aynı olmalı (1.x.x dosyaları 1.y.y
+//ile okunabilir); farklıysa veya dosya spt2 JSON çıktısı değilse hata döner.
+func ImportJSON(jsonPath string) (*models.TranscriptionResult, *OutputMetadata, error) {
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("JSON dosyası okunamadı: %w", err)
+	}
+
+	var jsonOutput JSONOutput
+	if err := json.Unmarshal(data, &jsonOutput); err != nil {
+		return nil, nil, fmt.Errorf("JSON parse edilemedi: %w", err)
+	}
+
+	metadata := jsonOutput.Metadata
+	if metadata.OutputFormat != "json" || jsonOutput.TranscriptionResult == nil {
+		return nil, nil, fmt.Errorf("spt2 JSON çıktısı değil: %s", jsonPath)
+	}
+	if !compatibleVersion(metadata.Version) {
+		return nil, nil, fmt.Errorf("desteklenmeyen JSON sürümü: %q (beklenen: %s ile uyumlu)", metadata.Version, FormatVersion)
+	}
+
+	return jsonOutput.TranscriptionResult, &metadata, nil
+}
+
+func compatibleVersion(version string) bool {
+	major, _, _ := strings.Cut(version, ".")
+	expectedMajor, _, _ := strings.Cut(FormatVersion, ".")
+	return version != "" && major == expectedMajor
+}
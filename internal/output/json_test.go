@@ -0,0 +1,113 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+func TestExportImportJSON(t *testing.T) {
+	dir := t.TempDir()
+	result := &models.TranscriptionResult{
+		Transcript:   "merhaba dünya",
+		LanguageCode: "tr-TR",
+		Words:        []models.WordInfo{{Word: "merhaba", EndTime: 0.5}, {Word: "dünya", StartTime: 0.5, EndTime: 1}},
+		Segments:     []models.Segment{{Transcript: "merhaba dünya", End: 1}},
+	}
+
+	path, err := ExportJSON(result, "/kayitlar/ders.wav", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "ders.json") {
+		t.Errorf("çıktı yolu = %s", path)
+	}
+
+	imported, metadata, err := ImportJSON(path)
+	if err != nil {
+		t.Fatalf("ImportJSON: %v", err)
+	}
+	if imported.Transcript != result.Transcript || len(imported.Words) != 2 || len(imported.Segments) != 1 {
+		t.Errorf("okunan sonuç = %+v", imported)
+	}
+	if metadata.AudioFile != "ders.wav" || metadata.Version != FormatVersion {
+		t.Errorf("metadata = %+v", metadata)
+	}
+}
+
+func TestImportJSONRejects(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"bozuk", `{"transcription_result":`, "parse edilemedi"},
+		{"başka JSON", `{"foo": 1}`, "spt2 JSON çıktısı değil"},
+		{"format json değil", `{"transcription_result": {}, "metadata": {"output_format": "srt", "version": "1.0.0"}}`, "spt2 JSON çıktısı değil"},
+		{"sonuç yok", `{"metadata": {"output_format": "json", "version": "1.0.0"}}`, "spt2 JSON çıktısı değil"},
+		{"sürüm yok", `{"transcription_result": {}, "metadata": {"output_format": "json"}}`, "desteklenmeyen JSON sürümü"},
+		{"yeni major", `{"transcription_result": {}, "metadata": {"output_format": "json", "version": "2.0.0"}}`, "desteklenmeyen JSON sürümü"},
+	}
+	for _, test := range tests {
+		path := filepath.Join(dir, "kayit.json")
+		if err := os.WriteFile(path, []byte(test.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := ImportJSON(path); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: hata %v, beklenen %q", test.name, err, test.want)
+		}
+	}
+
+	if _, _, err := ImportJSON(filepath.Join(dir, "yok.json")); err == nil {
+		t.Error("olmayan dosya için hata bekleniyordu")
+	}
+}
+
+func TestCompatibleVersion(t *testing.T) {
+	tests := map[string]bool{
+		FormatVersion: true,
+		"1.0.0":       true,
+		"1.9.3":       true,
+		"1":           true,
+		"0.9.0":       false,
+		"2.0.0":       false,
+		"10.0.0":      false,
+		"":            false,
+	}
+	for version, want := range tests {
+		if got := compatibleVersion(version); got != want {
+			t.Errorf("compatibleVersion(%q) = %t, beklenen %t", version, got, want)
+		}
+	}
+}
+
+func TestLookupFormats(t *testing.T) {
+	formats, err := LookupFormats(" SRT, txt,,json ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, format := range formats {
+		names = append(names, format.Name)
+	}
+	if strings.Join(names, ",") != "srt,txt,json" {
+		t.Errorf("formatlar = %v", names)
+	}
+
+	if _, err := LookupFormats("srt,docx"); err == nil || !strings.Contains(err.Error(), "docx") {
+		t.Errorf("bilinmeyen format hatası = %v", err)
+	}
+
+	cfg := &models.AppConfig{GenerateJSON: true, GenerateVTT: true}
+	var enabled []string
+	for _, format := range EnabledFormats(cfg) {
+		enabled = append(enabled, format.Name)
+	}
+	if strings.Join(enabled, ",") != "json,vtt" {
+		t.Errorf("açık formatlar = %v", enabled)
+	}
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"spt2/pkg/models"
+)
+
+// Exporter - deşifre sonucunu bir formatta dosyaya yazan fonksiyon
+//
+// audioFilePath yalnızca çıktı dosya adı için kullanılır (ses dosyasının var
+// olması gerekmez); çıktılar cfg.OutputDir altına yazılır. Düşük güvenli
+// kelimeler formatın low_confidence moduna göre işlenir (ApplyLowConfidence).
+type Exporter func(result *models.TranscriptionResult, audioFilePath string, cfg *models.AppConfig) (string, error)
+
+// Format - kayıtlı bir çıktı formatı
+type Format struct {
+	Name    string // "json", "srt", ...
+	Label   string // ilerleme mesajlarında kullanılır
+	Export  Exporter
+	Enabled func(cfg *models.AppConfig) bool // config'te açık mı (generate_* alanları)
+}
+
+// kayıtlı formatlar, kayıt (ve üretim) sırasıyla
+var formats []*Format
+
+// RegisterFormat - yeni bir çıktı formatı ekler
+func RegisterFormat(format Format) {
+	formats = append(formats, &format)
+}
+
+func init() {
+	RegisterFormat(Format{
+		Name:  "json",
+		Label: "JSON",
+		Export: func(result *models.TranscriptionResult, audioFilePath string, cfg *models.AppConfig) (string, error) {
+			return ExportJSON(ApplyLowConfidence(result, LowConfidenceMode(cfg, "json"), false), audioFilePath, cfg.OutputDir)
+		},
+		Enabled: func(cfg *models.AppConfig) bool { return cfg.GenerateJSON },
+	})
+	RegisterFormat(Format{
+		Name:  "srt",
+		Label: "SRT altyazı",
+		Export: func(result *models.TranscriptionResult, audioFilePath string, cfg *models.AppConfig) (string, error) {
+			return ExportSRT(ApplyLowConfidence(result, LowConfidenceMode(cfg, "srt"), true), audioFilePath, cfg.OutputDir, SubtitleOptionsFromConfig(cfg))
+		},
+		Enabled: func(cfg *models.AppConfig) bool { return cfg.GenerateSRT },
+	})
+	RegisterFormat(Format{
+		Name:  "txt",
+		Label: "TXT rapor",
+		Export: func(result *models.TranscriptionResult, audioFilePath string, cfg *models.AppConfig) (string, error) {
+			return ExportTXT(ApplyLowConfidence(result, LowConfidenceMode(cfg, "txt"), true), audioFilePath, cfg.OutputDir)
+		},
+		Enabled: func(cfg *models.AppConfig) bool { return cfg.GenerateTXT },
+	})
+	RegisterFormat(Format{
+		Name:  "vtt",
+		Label: "WebVTT altyazı",
+		Export: func(result *models.TranscriptionResult, audioFilePath string, cfg *models.AppConfig) (string, error) {
+			return ExportVTT(ApplyLowConfidence(result, LowConfidenceMode(cfg, "vtt"), true), audioFilePath, cfg.OutputDir, VTTOptionsFromConfig(cfg))
+		},
+		Enabled: func(cfg *models.AppConfig) bool { return cfg.GenerateVTT },
+	})
+}
+
+// OutputPath - formatın audioFilePath için yazdığı dosya: outputDir/<ad>.<format>
+//
+// Ad, ses dosyasının uzantısız adıdır; aynı adlı iki ses dosyası (ders.mp3,
+// ders.wav) aynı dizine aynı çıktıları yazar.
+func OutputPath(audioFilePath string, outputDir string, format string) string {
+	audioFileName := filepath.Base(audioFilePath)
+	return filepath.Join(outputDir, strings.TrimSuffix(audioFileName, filepath.Ext(audioFileName))+"."+format)
+}
+
+// Formats - kayıtlı formatlar, kayıt sırasıyla
+func Formats() []*Format {
+	return formats
+}
+
+// LookupFormat - adıyla format bulma
+func LookupFormat(name string) (*Format, bool) {
+	for _, format := range formats {
+		if format.Name == name {
+			return format, true
+		}
+	}
+	return nil, false
+}
+
+// EnabledFormats - config'te açık olan formatlar
+func EnabledFormats(cfg *models.AppConfig) []*Format {
+	var enabled []*Format
+	for _, format := range Formats() {
+		if format.Enabled(cfg) {
+			enabled = append(enabled, format)
+		}
+	}
+	return enabled
+}
+
+// LookupFormats - virgülle ayrılmış format adlarını çözme ("srt,txt")
+func LookupFormats(names string) ([]*Format, error) {
+	var selected []*Format
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		format, ok := LookupFormat(name)
+		if !ok {
+			return nil, fmt.Errorf("bilinmeyen çıktı formatı: %s (desteklenenler: %s)", name, strings.Join(FormatNames(), ", "))
+		}
+		selected = append(selected, format)
+	}
+	return selected, nil
+}
+
+// FormatNames - kayıtlı format adları
+func FormatNames() []string {
+	var names []string
+	for _, format := range Formats() {
+		names = append(names, format.Name)
+	}
+	return names
+}
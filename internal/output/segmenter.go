@@ -0,0 +1,341 @@
+package output
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"spt2/internal/analysis"
+	"spt2/pkg/models"
+)
+
+// ardışık iki cue arasında bırakılan en kısa boşluk (saniye, ~2 kare)
+const minCueGap = 0.08
+
+// segment sınırı ile kelime zamanları arasındaki tolerans (saniye)
+const breakTolerance = 0.05
+
+// SubtitleOptions - altyazı bölümleme limitleri (config: subtitle_*)
+//
+// Varsayılanlar yaygın yayın kılavuzlarına (EBU, Netflix) yakındır:
+// satır başına 42 karakter, 2 satır, 1-7 sn, saniyede en fazla 17 karakter.
+type SubtitleOptions struct {
+	MaxCharsPerLine      int
+	MaxLines             int
+	MinDuration          float64 // saniye
+	MaxDuration          float64 // saniye
+	MaxCPS               float64 // okuma hızı: saniyede karakter
+	PauseThreshold       float64 // bu süreden uzun sessizlikte yeni cue başlar (saniye)
+	SplitOnSpeakerChange bool
+
+	// API sonuç sınırları (Segment.End); bir sınırı geçen kelime yeni cue başlatır
+	Breaks []float64
+
+	// kaynak video ise (TranscriptionResult.FrameRate/VideoDuration): cue
+	// sınırları karelere hizalanır ve video süresini aşmaz
+	FrameRate     float64
+	VideoDuration float64
+}
+
+// SubtitleOptionsFromConfig - config'teki subtitle_* alanlarından ayarlar
+func SubtitleOptionsFromConfig(cfg *models.AppConfig) SubtitleOptions {
+	return SubtitleOptions{
+		MaxCharsPerLine:      cfg.SubtitleMaxCharsPerLine,
+		MaxLines:             cfg.SubtitleMaxLines,
+		MinDuration:          cfg.SubtitleMinDuration,
+		MaxDuration:          cfg.SubtitleMaxDuration,
+		MaxCPS:               cfg.SubtitleMaxCPS,
+		PauseThreshold:       cfg.SubtitlePauseThreshold,
+		SplitOnSpeakerChange: cfg.SubtitleSplitOnSpeaker,
+	}
+}
+
+// sıfır değerli alanlar için varsayılanlar (config'siz çağrılar için)
+func (options SubtitleOptions) withDefaults() SubtitleOptions {
+	if options.MaxCharsPerLine <= 0 {
+		options.MaxCharsPerLine = 42
+	}
+	if options.MaxLines <= 0 {
+		options.MaxLines = 2
+	}
+	if options.MaxDuration <= 0 {
+		options.MaxDuration = 7
+	}
+	if options.MaxCPS <= 0 {
+		options.MaxCPS = 17
+	}
+	if options.PauseThreshold <= 0 {
+		options.PauseThreshold = 0.8
+	}
+	return options
+}
+
+// Cue - tek bir altyazı
+type Cue struct {
+	Start float64
+	End   float64
+	Lines [][]models.WordInfo // satırlara yerleştirilmiş kelimeler
+}
+
+// Words - cue'daki tüm kelimeler
+func (cue Cue) Words() []models.WordInfo {
+	var words []models.WordInfo
+	for _, line := range cue.Lines {
+		words = append(words, line...)
+	}
+	return words
+}
+
+// SegmentSubtitles - kelimeleri altyazı cue'larına bölme
+//
+// KURALLAR:
+//   - cümle sonu noktalaması (. ? ! …) cue'yu bitirir
+//   - PauseThreshold'dan uzun sessizlik, API sonuç sınırları (Breaks) ve
+//     (isteğe bağlı) konuşmacı değişimi yeni cue başlatır
+//   - metin MaxLines x MaxCharsPerLine'a sığmazsa, süre MaxDuration'ı aşarsa
+//     veya okuma hızı MaxCPS'i aşarsa cue bölünür; mümkünse ikinci yarıdaki
+//     son virgülden
+//   - cue bitişleri, okuma hızı MaxCPS'i ve MinDuration'ı sağlayacak kadar
+//     sonraki sessizliğe uzatılır (bir sonraki cue'ya taşmadan)
+//   - FrameRate verilmişse başlangıç ve bitişler en yakın kareye hizalanır;
+//     VideoDuration verilmişse bu süreden sonra başlayan cue'lar atılır,
+//     bitişler video süresine kırpılır
+func SegmentSubtitles(words []models.WordInfo, options SubtitleOptions) []Cue {
+	options = options.withDefaults()
+
+	var groups [][]models.WordInfo
+	var current []models.WordInfo
+	nextBreak := 0
+
+	for _, word := range words {
+		crossed := false
+		for nextBreak < len(options.Breaks) && options.Breaks[nextBreak] <= word.StartTime+breakTolerance {
+			crossed = true
+			nextBreak++
+		}
+
+		if len(current) > 0 {
+			last := current[len(current)-1]
+			if (crossed && last.EndTime <= options.Breaks[nextBreak-1]+breakTolerance) ||
+				word.StartTime-last.EndTime > options.PauseThreshold ||
+				(options.SplitOnSpeakerChange && word.SpeakerTag != last.SpeakerTag) {
+				groups = append(groups, current)
+				current = nil
+			}
+		}
+
+		if len(current) > 0 && !options.fits(append(current[:len(current):len(current)], word)) {
+			head, tail := splitAtClause(current)
+			groups = append(groups, head)
+			current = tail
+			if len(current) > 0 && !options.fits(append(current[:len(current):len(current)], word)) {
+				groups = append(groups, current)
+				current = nil
+			}
+		}
+
+		current = append(current, word)
+
+		if analysis.EndsSentence(word.Word) {
+			groups = append(groups, current)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+
+	cues := make([]Cue, 0, len(groups))
+	for _, group := range groups {
+		lines, _ := layoutLines(group, options.MaxCharsPerLine, options.MaxLines)
+		cues = append(cues, Cue{
+			Start: group[0].StartTime,
+			End:   group[len(group)-1].EndTime,
+			Lines: lines,
+		})
+	}
+
+	adjustCueTimings(cues, options)
+	return snapCues(cues, options)
+}
+
+// segmentBreaks - API sonuçlarının bitiş zamanları (sıralı)
+func segmentBreaks(segments []models.Segment) []float64 {
+	var breaks []float64
+	for _, segment := range segments {
+		if segment.End > 0 {
+			breaks = append(breaks, segment.End)
+		}
+	}
+	sort.Float64s(breaks)
+	return breaks
+}
+
+// kelimeler cue limitlerine (satır düzeni, süre ve okuma hızı) sığıyor mu
+//
+// Okuma hızı için kullanılabilir süre kelimelerin kapladığı süredir, en az
+// MinDuration (cue o kadar uzatılabilir). Metin MaxCPS x bu süreyi aşarsa
+// cue bölünür; tek kelimelik cue bölünemeyeceği için her zaman sığar.
+func (options SubtitleOptions) fits(words []models.WordInfo) bool {
+	duration := words[len(words)-1].EndTime - words[0].StartTime
+	if duration > options.MaxDuration {
+		return false
+	}
+	if float64(textLength(words)) > options.MaxCPS*math.Max(duration, options.MinDuration) {
+		return false
+	}
+	_, ok := layoutLines(words, options.MaxCharsPerLine, options.MaxLines)
+	return ok
+}
+
+// cue taşınca bölme noktası: ikinci yarıdaki son virgül/noktalı virgül,
+// yoksa tamamı önceki cue'da kalır
+func splitAtClause(words []models.WordInfo) (head []models.WordInfo, tail []models.WordInfo) {
+	for i := len(words) - 2; i >= len(words)/2; i-- {
+		if endsClause(words[i].Word) {
+			return words[:i+1], append([]models.WordInfo(nil), words[i+1:]...)
+		}
+	}
+	return words, nil
+}
+
+// kelimeleri en fazla maxLines satıra yerleştirme
+//
+// İki satırlık düzende satırlar dengelenir (en uzun satırı en kısa yapan
+// bölme noktası); sığmıyorsa ok=false ile açgözlü yerleşim döner.
+func layoutLines(words []models.WordInfo, maxChars int, maxLines int) ([][]models.WordInfo, bool) {
+	if textLength(words) <= maxChars {
+		return [][]models.WordInfo{words}, true
+	}
+
+	if maxLines == 2 {
+		best := -1
+		bestLength := 0
+		for i := 1; i < len(words); i++ {
+			longest := textLength(words[:i])
+			if second := textLength(words[i:]); second > longest {
+				longest = second
+			}
+			if longest <= maxChars && (best < 0 || longest < bestLength) {
+				best, bestLength = i, longest
+			}
+		}
+		if best > 0 {
+			return [][]models.WordInfo{words[:best], words[best:]}, true
+		}
+	}
+
+	var lines [][]models.WordInfo
+	start := 0
+	for i := 1; i <= len(words); i++ {
+		if i == len(words) || textLength(words[start:i+1]) > maxChars {
+			lines = append(lines, words[start:i])
+			start = i
+		}
+	}
+	return lines, len(lines) <= maxLines
+}
+
+// min süre ve okuma hızı için cue bitişlerini uzatma
+func adjustCueTimings(cues []Cue, options SubtitleOptions) {
+	for i := range cues {
+		cue := &cues[i]
+
+		required := math.Max(float64(cueTextLength(*cue))/options.MaxCPS, options.MinDuration)
+		if cue.End-cue.Start >= required {
+			continue
+		}
+
+		end := cue.Start + math.Min(required, options.MaxDuration)
+		if i+1 < len(cues) {
+			end = math.Min(end, cues[i+1].Start-minCueGap)
+		}
+		cue.End = math.Max(cue.End, end)
+	}
+}
+
+// cue sınırlarını video karelerine hizalama ve video süresine kırpma
+//
+// Hizalanmış zaman, karenin başlangıcından önceki milisaniyeye düşmesin diye
+// yukarı yuvarlanır (29.97 fps'te 1. kare: 0.0334). Kareye yuvarlanınca
+// üst üste binen cue'larda önceki cue sonrakinin başladığı karede biter.
+func snapCues(cues []Cue, options SubtitleOptions) []Cue {
+	if options.FrameRate <= 0 && options.VideoDuration <= 0 {
+		return cues
+	}
+
+	snapped := cues[:0]
+	for _, cue := range cues {
+		if options.VideoDuration > 0 {
+			if cue.Start >= options.VideoDuration {
+				break
+			}
+			cue.End = math.Min(cue.End, options.VideoDuration)
+		}
+		if options.FrameRate > 0 {
+			startFrame := math.Round(cue.Start * options.FrameRate)
+			endFrame := math.Round(cue.End * options.FrameRate)
+			if options.VideoDuration > 0 {
+				endFrame = math.Min(endFrame, math.Floor(options.VideoDuration*options.FrameRate+1e-6))
+			}
+			if endFrame <= startFrame {
+				endFrame = startFrame + 1
+			}
+			cue.Start = frameTime(startFrame, options.FrameRate)
+			cue.End = frameTime(endFrame, options.FrameRate)
+		}
+
+		if last := len(snapped) - 1; last >= 0 && snapped[last].End > cue.Start {
+			snapped[last].End = cue.Start
+		}
+		snapped = append(snapped, cue)
+	}
+
+	// çakışma düzeltmesi veya kırpma sonrası süresiz kalan cue'lar atılır
+	valid := snapped[:0]
+	for _, cue := range snapped {
+		if cue.End > cue.Start {
+			valid = append(valid, cue)
+		}
+	}
+	return valid
+}
+
+// karenin başlangıç zamanı, milisaniyeye yukarı yuvarlanmış
+func frameTime(frame float64, frameRate float64) float64 {
+	return math.Ceil(frame/frameRate*1000-1e-6) / 1000
+}
+
+// satırdaki metnin karakter sayısı (kelimeler arası boşluklar dahil)
+func textLength(words []models.WordInfo) int {
+	length := 0
+	for i, word := range words {
+		if i > 0 {
+			length++
+		}
+		length += utf8.RuneCountInString(word.Word)
+	}
+	return length
+}
+
+func cueTextLength(cue Cue) int {
+	length := 0
+	for _, line := range cue.Lines {
+		length += textLength(line)
+	}
+	return length
+}
+
+// satır metni
+func lineText(words []models.WordInfo) string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.Word
+	}
+	return strings.Join(texts, " ")
+}
+
+func endsClause(word string) bool {
+	return strings.HasSuffix(word, ",") || strings.HasSuffix(word, ";") || strings.HasSuffix(word, ":")
+}
@@ -0,0 +1,185 @@
+package output
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+var testSubtitleOptions = SubtitleOptions{
+	MaxCharsPerLine: 42,
+	MaxLines:        2,
+	MinDuration:     1,
+	MaxDuration:     7,
+	MaxCPS:          17,
+	PauseThreshold:  0.8,
+}
+
+// metindeki kelimeler start'tan başlayarak arka arkaya, her biri step saniye
+func timedWords(text string, start float64, step float64, speaker int32) []models.WordInfo {
+	var words []models.WordInfo
+	for i, token := range strings.Fields(text) {
+		words = append(words, models.WordInfo{
+			Word:       token,
+			StartTime:  start + float64(i)*step,
+			EndTime:    start + float64(i+1)*step,
+			Confidence: 0.9,
+			SpeakerTag: speaker,
+		})
+	}
+	return words
+}
+
+func cueTexts(cues []Cue) []string {
+	texts := make([]string, len(cues))
+	for i, cue := range cues {
+		texts[i] = lineText(cue.Words())
+	}
+	return texts
+}
+
+func checkCueTexts(t *testing.T, cues []Cue, want []string) {
+	t.Helper()
+	got := cueTexts(cues)
+	if len(got) != len(want) {
+		t.Fatalf("cue'lar = %q, beklenen %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d. cue = %q, beklenen %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSegmentSubtitlesPunctuationBreak(t *testing.T) {
+	words := timedWords("Merhaba arkadaşlar. Bugün ne yapıyoruz?", 0, 0.6, 0)
+	cues := SegmentSubtitles(words, testSubtitleOptions)
+	checkCueTexts(t, cues, []string{"Merhaba arkadaşlar.", "Bugün ne yapıyoruz?"})
+}
+
+func TestSegmentSubtitlesPauseBreak(t *testing.T) {
+	words := append(timedWords("ilk cümle burada", 0, 0.5, 0), timedWords("ikinci kısım", 3, 0.5, 0)...)
+	cues := SegmentSubtitles(words, testSubtitleOptions)
+	checkCueTexts(t, cues, []string{"ilk cümle burada", "ikinci kısım"})
+
+	// ilk cue sessizliğe uzatılabilir ama bir sonrakine taşmamalı
+	if cues[0].End > cues[1].Start-minCueGap+1e-9 {
+		t.Errorf("ilk cue %.2f'de bitiyor, ikinci %.2f'de başlıyor", cues[0].End, cues[1].Start)
+	}
+}
+
+func TestSegmentSubtitlesSegmentBreak(t *testing.T) {
+	words := timedWords("bir iki üç dört", 0, 0.5, 0)
+	options := testSubtitleOptions
+	options.Breaks = []float64{1.0}
+	cues := SegmentSubtitles(words, options)
+	checkCueTexts(t, cues, []string{"bir iki", "üç dört"})
+}
+
+func TestSegmentSubtitlesSpeakerBreak(t *testing.T) {
+	words := append(timedWords("nasılsın bugün", 0, 0.5, 1), timedWords("iyiyim sağ ol", 1, 0.5, 2)...)
+
+	options := testSubtitleOptions
+	options.SplitOnSpeakerChange = true
+	checkCueTexts(t, SegmentSubtitles(words, options), []string{"nasılsın bugün", "iyiyim sağ ol"})
+
+	options.SplitOnSpeakerChange = false
+	checkCueTexts(t, SegmentSubtitles(words, options), []string{"nasılsın bugün iyiyim sağ ol"})
+}
+
+func TestSegmentSubtitlesTwoLineLimit(t *testing.T) {
+	text := strings.Repeat("bu altyazı satırları dengeli olmalı ", 6)
+	words := timedWords(text, 0, 0.4, 0)
+	options := testSubtitleOptions
+	options.MaxDuration = 30
+	options.MaxCPS = 100
+
+	cues := SegmentSubtitles(words, options)
+	if len(cues) < 2 {
+		t.Fatalf("uzun metin bölünmedi: %q", cueTexts(cues))
+	}
+	total := 0
+	for i, cue := range cues {
+		if len(cue.Lines) > 2 {
+			t.Errorf("%d. cue %d satır", i, len(cue.Lines))
+		}
+		for _, line := range cue.Lines {
+			if length := textLength(line); length > 42 {
+				t.Errorf("%d. cue'da %d karakterlik satır: %q", i, length, lineText(line))
+			}
+		}
+		total += len(cue.Words())
+	}
+	if total != len(words) {
+		t.Errorf("cue'lardaki kelime sayısı %d, beklenen %d", total, len(words))
+	}
+}
+
+// hızlı konuşma (~35 karakter/sn) okuma hızı sınırı için bölünmeli
+func TestSegmentSubtitlesMaxCPS(t *testing.T) {
+	words := timedWords(strings.Repeat("hızlı konuşan biri ", 8), 0, 0.2, 0)
+	cues := SegmentSubtitles(words, testSubtitleOptions)
+	if len(cues) < 3 {
+		t.Fatalf("hızlı konuşma bölünmedi: %q", cueTexts(cues))
+	}
+
+	for i, cue := range cues {
+		cueWords := cue.Words()
+		if len(cueWords) < 2 {
+			continue
+		}
+		span := cueWords[len(cueWords)-1].EndTime - cueWords[0].StartTime
+		if cps := float64(textLength(cueWords)) / math.Max(span, 1); cps > 17+1e-9 {
+			t.Errorf("%d. cue %.1f karakter/sn: %q", i, cps, lineText(cueWords))
+		}
+	}
+}
+
+// kısa cue okuma hızı ve en kısa süre için uzatılmalı
+func TestSegmentSubtitlesExtendsShortCues(t *testing.T) {
+	words := append(timedWords("Evet.", 0, 0.2, 0), timedWords("Anlaşılmıştır.", 5, 0.3, 0)...)
+	options := testSubtitleOptions
+	options.MinDuration = 0.5
+
+	cues := SegmentSubtitles(words, options)
+	checkCueTexts(t, cues, []string{"Evet.", "Anlaşılmıştır."})
+
+	if cues[0].End != 0.5 {
+		t.Errorf("ilk cue %.2f'de bitiyor, beklenen 0.50 (en kısa süre)", cues[0].End)
+	}
+	if want := 5 + 14.0/17; math.Abs(cues[1].End-want) > 1e-9 {
+		t.Errorf("ikinci cue %.2f'de bitiyor, beklenen %.2f (okuma hızı)", cues[1].End, want)
+	}
+}
+
+// video kaynağında cue sınırları karelere hizalanmalı ve video süresini aşmamalı
+func TestSegmentSubtitlesSnapsToFrames(t *testing.T) {
+	words := append(timedWords("Birinci cümle.", 0.51, 0.6, 0), timedWords("İkinci cümle.", 9.02, 0.6, 0)...)
+	words = append(words, timedWords("Videodan sonra.", 12, 0.6, 0)...)
+	options := testSubtitleOptions
+	options.FrameRate = 30000.0 / 1001
+	options.VideoDuration = 10
+
+	cues := SegmentSubtitles(words, options)
+	checkCueTexts(t, cues, []string{"Birinci cümle.", "İkinci cümle."})
+
+	for i, cue := range cues {
+		for _, value := range []float64{cue.Start, cue.End} {
+			frame := math.Round(value * options.FrameRate)
+			if math.Abs(value-frameTime(frame, options.FrameRate)) > 1e-9 || value*1000 < frame/options.FrameRate*1000-1e-6 {
+				t.Errorf("%d. cue zamanı %.3f bir kare başlangıcı değil", i, value)
+			}
+		}
+	}
+	if cues[0].Start != 0.501 {
+		t.Errorf("ilk cue %.3f'de başlıyor, beklenen 0.501 (15. kare)", cues[0].Start)
+	}
+	if cues[1].End > 10 {
+		t.Errorf("son cue %.3f'de bitiyor, video süresi 10", cues[1].End)
+	}
+	if formatSRTTime(cues[0].Start) != "00:00:00,501" {
+		t.Errorf("SRT zamanı = %s", formatSRTTime(cues[0].Start))
+	}
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +11,41 @@ import (
 	"spt2/pkg/models"
 )
 
+// FormatClock - süre gösterimi: 75.4 → "01:15", 3725 → "1:02:05"
+func FormatClock(seconds float64) string {
+	total := int64(math.Round(seconds))
+	if total >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", total/3600, total/60%60, total%60)
+	}
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
+
+// segmentlerde tespit edilen dillerin süre payları ("tr-TR %80", "en-US %20")
+func languageShares(segments []models.Segment) []string {
+	durations := make(map[string]float64)
+	var order []string
+	var total float64
+	for _, segment := range segments {
+		if segment.LanguageCode == "" {
+			continue
+		}
+		if _, ok := durations[segment.LanguageCode]; !ok {
+			order = append(order, segment.LanguageCode)
+		}
+		durations[segment.LanguageCode] += segment.End - segment.Start
+		total += segment.End - segment.Start
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	shares := make([]string, len(order))
+	for i, code := range order {
+		shares[i] = fmt.Sprintf("%s %%%.0f", code, durations[code]/total*100)
+	}
+	return shares
+}
+
 func ExportTXT(result *models.TranscriptionResult, audioFilePath string, outputDir string) (string, error) {
 	if err := os.MkdirAll(outputDir, 0755); err != nil{
 		return "", fmt.Errorf("output dizini oluşturulamadı: %w", err)
@@ -21,6 +57,9 @@ func ExportTXT(result *models.TranscriptionResult, audioFilePath string, outputD
 	txtContent.WriteString(fmt.Sprintf("Ses Dosyası: %s\n", filepath.Base(audioFilePath)))
 	txtContent.WriteString(fmt.Sprintf("Tarih: %s\n", time.Now().Format("2006-01-02 15:04:05")))
 	txtContent.WriteString(fmt.Sprintf("Dil: %s\n", result.LanguageCode))
+	if languages := languageShares(result.Segments); len(languages) > 1 {
+		txtContent.WriteString(fmt.Sprintf("Tespit Edilen Diller: %s\n", strings.Join(languages, ", ")))
+	}
 	txtContent.WriteString(fmt.Sprintf("Toplam Kelime: %d\n\n", len(result.Words)))
 
 	txtContent.WriteString("--- TAM DEŞİFRE METNİ ---\n\n")
@@ -37,14 +76,58 @@ func ExportTXT(result *models.TranscriptionResult, audioFilePath string, outputD
 
 		txtContent.WriteString("--- İSTATİSTİKLER ---\n\n")
 		txtContent.WriteString(fmt.Sprintf("Ortalama Güven: %.1f\n", avgConfidence))
+		if result.Confidence > 0 {
+			txtContent.WriteString(fmt.Sprintf("Genel Güven (segment ağırlıklı): %.1f\n", result.Confidence*100))
+		}
 		txtContent.WriteString(fmt.Sprintf("Toplam Kelime Sayısı: %d\n", len(result.Words)))
+		if lowConfidence := lowConfidenceCount(result.Words); lowConfidence > 0 {
+			txtContent.WriteString(fmt.Sprintf("Düşük Güvenli Kelime: %d\n", lowConfidence))
+		}
+	}
+
+	if len(result.Speakers) > 0 {
+		txtContent.WriteString("\n--- KONUŞMACI ANALİZİ ---\n\n")
+		for _, speaker := range result.Speakers {
+			txtContent.WriteString(fmt.Sprintf("%s\n", speakerLabel(result.Speakers, speaker.SpeakerTag)))
+			txtContent.WriteString(fmt.Sprintf("  Konuşma Süresi: %s (%%%.1f)\n", FormatClock(speaker.TotalDuration), speaker.TalkRatio*100))
+			txtContent.WriteString(fmt.Sprintf("  Kelime: %d (%.0f kelime/dk)\n", speaker.WordCount, speaker.WordsPerMinute))
+			txtContent.WriteString(fmt.Sprintf("  Söz Alma: %d\n", speaker.Turns))
+			txtContent.WriteString(fmt.Sprintf("  En Uzun Konuşma: %s (başlangıç %s)\n", FormatClock(speaker.LongestMonologue), FormatClock(speaker.LongestMonologueStart)))
+			txtContent.WriteString(fmt.Sprintf("  Söz Kesme: %d, Sözü Kesilme: %d\n\n", speaker.Interruptions, speaker.Interrupted))
+		}
+
+		for _, speaker := range result.Speakers {
+			txtContent.WriteString(fmt.Sprintf("[%s]\n%s\n\n", speakerLabel(result.Speakers, speaker.SpeakerTag), speaker.Transcript))
+		}
+	}
+
+	if len(result.KeywordMatches) > 0 {
+		txtContent.WriteString("\n--- ANAHTAR KELİMELER ---\n\n")
+
+		counts := make(map[string]int)
+		var order []string
+		for _, match := range result.KeywordMatches {
+			if counts[match.Keyword] == 0 {
+				order = append(order, match.Keyword)
+			}
+			counts[match.Keyword]++
+		}
+		for _, keyword := range order {
+			txtContent.WriteString(fmt.Sprintf("%s: %d kez\n", keyword, counts[keyword]))
+		}
+		txtContent.WriteString("\n")
+
+		for _, match := range result.KeywordMatches {
+			txtContent.WriteString(fmt.Sprintf("[%s] %s", FormatClock(match.Timestamp), match.Keyword))
+			if match.SpeakerTag > 0 {
+				txtContent.WriteString(fmt.Sprintf(" (%s)", speakerLabel(result.Speakers, match.SpeakerTag)))
+			}
+			txtContent.WriteString(fmt.Sprintf(": ...%s...\n", match.Context))
+		}
 	}
 
 	// Çıktı dosya adı oluştur (her zaman, Words olsun olmasın)
-	audioFileName := filepath.Base(audioFilePath)
-	audioFileNameWithoutExt := audioFileName[:len(audioFileName)-len(filepath.Ext(audioFileName))]
-	outputFileName := audioFileNameWithoutExt + ".txt"
-	outputPath := filepath.Join(outputDir, outputFileName)
+	outputPath := OutputPath(audioFilePath, outputDir, "txt")
 
 	// Dosyaya yaz
 	if err := os.WriteFile(outputPath, []byte(txtContent.String()), 0644); err != nil {
@@ -0,0 +1,171 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"spt2/pkg/models"
+)
+
+// VTTOptions - WebVTT çıktısının ayarları (config: vtt_*)
+type VTTOptions struct {
+	Position  string // cue position, örn: "50%"
+	Line      string // cue line, örn: "85%" veya "-2"
+	Align     string // start, center, end, left, right
+	VoiceTags bool   // konuşmacıları <v Konuşmacı N> ile işaretle
+	Karaoke   bool   // her kelimeden önce <00:00:01.000> zaman damgası
+	Note      string // başlıktan sonra NOTE bloğu
+	Style     string // STYLE bloğu içeriği (CSS, örn: "::cue { color: yellow; }")
+
+	Segmentation SubtitleOptions
+}
+
+// VTTOptionsFromConfig - config'teki vtt_* alanlarından ayarlar
+func VTTOptionsFromConfig(cfg *models.AppConfig) VTTOptions {
+	return VTTOptions{
+		Position:  cfg.VTTPosition,
+		Line:      cfg.VTTLine,
+		Align:     cfg.VTTAlign,
+		VoiceTags: cfg.VTTVoiceTags,
+		Karaoke:   cfg.VTTKaraoke,
+		Note:      cfg.VTTNote,
+		Style:     cfg.VTTStyle,
+
+		Segmentation: SubtitleOptionsFromConfig(cfg),
+	}
+}
+
+// WebVTT zaman formatı: 00:01:02.345
+func formatVTTTime(seconds float64) string {
+	totalMillis := int64(math.Round(seconds * 1000))
+	hours := totalMillis / 3600000
+	minutes := totalMillis / 60000 % 60
+	secs := totalMillis / 1000 % 60
+	millis := totalMillis % 1000
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", hours, minutes, secs, millis)
+}
+
+// cue metninde özel anlamı olan karakterler
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// konuşmacı etiketinden okunabilir ad: çok kanallı modda kanal adı
+// (SpeakerInfo.Name), yoksa "Konuşmacı N"
+func speakerLabel(speakers []models.SpeakerInfo, tag int32) string {
+	for _, speaker := range speakers {
+		if speaker.SpeakerTag == tag && speaker.Name != "" {
+			return speaker.Name
+		}
+	}
+	return fmt.Sprintf("Konuşmacı %d", tag)
+}
+
+// cue zamanlama satırından sonra gelen ayarlar ("position:50% align:center")
+func (options VTTOptions) cueSettings() string {
+	var settings []string
+	if options.Position != "" {
+		settings = append(settings, "position:"+options.Position)
+	}
+	if options.Line != "" {
+		settings = append(settings, "line:"+options.Line)
+	}
+	if options.Align != "" {
+		settings = append(settings, "align:"+options.Align)
+	}
+	return strings.Join(settings, " ")
+}
+
+// ExportVTT - deşifre sonucunu WebVTT altyazı dosyası olarak kaydetme
+//
+// Kelimeler SRT ile aynı şekilde (SegmentSubtitles) bölümlenir.
+// VoiceTags açıksa cue konuşmacının <v> etiketiyle başlar, cue içinde
+// konuşmacı değişirse önceki etiket kapatılıp yenisi açılır; Karaoke açıksa
+// ilk kelime dışındaki her kelimenin önüne başlangıç zamanı yazılır.
+func ExportVTT(result *models.TranscriptionResult, audioFilePath string, outputDir string, options VTTOptions) (string, error) {
+	if len(result.Words) == 0 {
+		return "", fmt.Errorf("VTT oluşturmak için kelime zaman damgaları gerekli (Words boş)")
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("output dizini oluşturulamadı: %w", err)
+	}
+
+	var vttContent strings.Builder
+	vttContent.WriteString("WEBVTT\n\n")
+
+	if options.Style != "" {
+		vttContent.WriteString("STYLE\n")
+		vttContent.WriteString(strings.TrimSpace(options.Style))
+		vttContent.WriteString("\n\n")
+	}
+	if options.Note != "" {
+		// "-->" NOTE bloğunda yasak; blok içinde boş satır da bloğu bitirir
+		note := strings.ReplaceAll(strings.TrimSpace(options.Note), "-->", "->")
+		note = strings.ReplaceAll(note, "\n\n", "\n")
+		vttContent.WriteString("NOTE\n")
+		vttContent.WriteString(note)
+		vttContent.WriteString("\n\n")
+	}
+
+	settings := options.cueSettings()
+	options.Segmentation.Breaks = segmentBreaks(result.Segments)
+	options.Segmentation.FrameRate = result.FrameRate
+	options.Segmentation.VideoDuration = result.VideoDuration
+
+	for i, cue := range SegmentSubtitles(result.Words, options.Segmentation) {
+		vttContent.WriteString(fmt.Sprintf("%d\n", i+1))
+
+		timing := fmt.Sprintf("%s --> %s", formatVTTTime(cue.Start), formatVTTTime(cue.End))
+		if settings != "" {
+			timing += " " + settings
+		}
+		vttContent.WriteString(timing + "\n")
+
+		vttContent.WriteString(vttCueText(cue, result.Speakers, options))
+		vttContent.WriteString("\n\n")
+	}
+
+	outputPath := OutputPath(audioFilePath, outputDir, "vtt")
+
+	if err := os.WriteFile(outputPath, []byte(vttContent.String()), 0644); err != nil {
+		return "", fmt.Errorf("VTT dosyası yazılamadı: %w", err)
+	}
+	return outputPath, nil
+}
+
+// cue metni: satırlar, isteğe bağlı konuşmacı etiketleri ve karaoke zamanları
+func vttCueText(cue Cue, speakers []models.SpeakerInfo, options VTTOptions) string {
+	var text strings.Builder
+	currentSpeaker := int32(0)
+	first := true
+
+	for lineIndex, line := range cue.Lines {
+		for wordIndex, word := range line {
+			changed := options.VoiceTags && word.SpeakerTag > 0 && word.SpeakerTag != currentSpeaker
+			if changed && currentSpeaker > 0 {
+				text.WriteString("</v>")
+			}
+
+			if wordIndex > 0 {
+				text.WriteString(" ")
+			} else if lineIndex > 0 {
+				text.WriteString("\n")
+			}
+
+			if changed {
+				currentSpeaker = word.SpeakerTag
+				text.WriteString(fmt.Sprintf("<v %s>", vttEscaper.Replace(speakerLabel(speakers, word.SpeakerTag))))
+			}
+
+			if options.Karaoke && !first {
+				text.WriteString(fmt.Sprintf("<%s>", formatVTTTime(word.StartTime)))
+			}
+			text.WriteString(vttEscaper.Replace(word.Word))
+			first = false
+		}
+	}
+
+	return text.String()
+}
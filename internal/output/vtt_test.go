@@ -0,0 +1,96 @@
+package output
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+func TestFormatVTTTime(t *testing.T) {
+	tests := map[float64]string{
+		0:       "00:00:00.000",
+		1.2345:  "00:00:01.235",
+		62.5:    "00:01:02.500",
+		3725.01: "01:02:05.010",
+		59.9996: "00:01:00.000",
+	}
+	for seconds, want := range tests {
+		if got := formatVTTTime(seconds); got != want {
+			t.Errorf("formatVTTTime(%v) = %s, beklenen %s", seconds, got, want)
+		}
+	}
+}
+
+func TestCueSettings(t *testing.T) {
+	if got := (VTTOptions{}).cueSettings(); got != "" {
+		t.Errorf("ayarsız cue = %q", got)
+	}
+	options := VTTOptions{Position: "50%", Line: "-2", Align: "center"}
+	if got := options.cueSettings(); got != "position:50% line:-2 align:center" {
+		t.Errorf("cue ayarları = %q", got)
+	}
+}
+
+func TestVTTCueText(t *testing.T) {
+	cue := Cue{Lines: [][]models.WordInfo{
+		{{Word: "Merhaba", StartTime: 0, SpeakerTag: 1}, {Word: "<herkes>", StartTime: 0.5, SpeakerTag: 1}},
+		{{Word: "Selam", StartTime: 1, SpeakerTag: 2}, {Word: "&", StartTime: 1.5, SpeakerTag: 2}},
+	}}
+	speakers := []models.SpeakerInfo{{SpeakerTag: 1, Name: "Hoca"}}
+
+	tests := []struct {
+		name    string
+		options VTTOptions
+		want    string
+	}{
+		{"düz", VTTOptions{}, "Merhaba &lt;herkes&gt;\nSelam &amp;"},
+		{"konuşmacı etiketleri", VTTOptions{VoiceTags: true},
+			"<v Hoca>Merhaba &lt;herkes&gt;</v>\n<v Konuşmacı 2>Selam &amp;"},
+		{"karaoke", VTTOptions{Karaoke: true},
+			"Merhaba <00:00:00.500>&lt;herkes&gt;\n<00:00:01.000>Selam <00:00:01.500>&amp;"},
+	}
+	for _, test := range tests {
+		if got := vttCueText(cue, speakers, test.options); got != test.want {
+			t.Errorf("%s:\n%q\nbeklenen\n%q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestExportVTT(t *testing.T) {
+	result := &models.TranscriptionResult{Words: []models.WordInfo{
+		{Word: "Merhaba.", StartTime: 0, EndTime: 0.8, SpeakerTag: 1},
+		{Word: "Nasılsın?", StartTime: 3, EndTime: 3.8, SpeakerTag: 2},
+	}}
+	options := VTTOptions{
+		Align: "center",
+		Note:  "ders kaydı --> taslak\n\nikinci satır",
+		Style: "::cue { color: yellow; }",
+	}
+
+	path, err := ExportVTT(result, "ders.wav", t.TempDir(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+
+	for _, want := range []string{
+		"WEBVTT\n\nSTYLE\n::cue { color: yellow; }\n\nNOTE\nders kaydı -> taslak\nikinci satır\n\n",
+		"1\n00:00:00.000 --> ",
+		" align:center\nMerhaba.\n\n2\n00:00:03.000 --> ",
+		"Nasılsın?\n\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("VTT içinde %q yok:\n%s", want, content)
+		}
+	}
+
+	if _, err := ExportVTT(&models.TranscriptionResult{}, "ders.wav", t.TempDir(), options); err == nil {
+		t.Error("kelimesiz sonuç için hata bekleniyordu")
+	}
+}
@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"spt2/internal/jobs"
+	"spt2/pkg/models"
+)
+
+// Submit'te sabit bir işlem adı veren, Resume'da verilen hatayı döndüren motor
+type failingAsyncRecognizer struct {
+	err      error
+	submits  int
+	resumed  []string
+	onResume func()
+}
+
+func (r *failingAsyncRecognizer) Recognize(ctx context.Context, metadata *models.AudioMetadata, audioURI string, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	return nil, errors.New("senkron tanıma beklenmiyordu")
+}
+
+func (r *failingAsyncRecognizer) Submit(ctx context.Context, metadata *models.AudioMetadata, audioURI string, cfg *models.AppConfig) (string, error) {
+	r.submits++
+	return "operations/1", nil
+}
+
+func (r *failingAsyncRecognizer) Resume(ctx context.Context, operationName string, metadata *models.AudioMetadata, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	r.resumed = append(r.resumed, operationName)
+	if r.onResume != nil {
+		r.onResume()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, r.err
+}
+
+func (r *failingAsyncRecognizer) Close() error {
+	return nil
+}
+
+func newOperationTestJob(t *testing.T) (*Pipeline, *jobs.Manifest) {
+	t.Helper()
+	store := jobs.NewStore(t.TempDir())
+	job, err := store.Create("ders.wav", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	job.AudioURI = "memory://bucket/ders.flac"
+	job.Stage = jobs.StageUploaded
+	if err := store.Save(job); err != nil {
+		t.Fatal(err)
+	}
+	return &Pipeline{Config: &models.AppConfig{}, Jobs: store}, job
+}
+
+// işlem hatayla biterse kayıt silinmeli; resume yeni işlem başlatmalı
+func TestRecognizeClearsFailedOperation(t *testing.T) {
+	p, job := newOperationTestJob(t)
+	recognizer := &failingAsyncRecognizer{err: errors.New("INVALID_ARGUMENT: bozuk ses")}
+	p.Recognizer = recognizer
+
+	if _, err := p.recognize(context.Background(), job, &models.AudioMetadata{}, p.Config); err == nil {
+		t.Fatal("hata bekleniyordu")
+	}
+
+	saved, err := p.Jobs.Load(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.OperationName != "" || saved.Stage != jobs.StageUploaded {
+		t.Errorf("kayıt: işlem %q, aşama %s; beklenen boş, %s", saved.OperationName, saved.Stage, jobs.StageUploaded)
+	}
+
+	if _, err := p.recognize(context.Background(), saved, &models.AudioMetadata{}, p.Config); err == nil {
+		t.Fatal("hata bekleniyordu")
+	}
+	if recognizer.submits != 2 {
+		t.Errorf("Submit %d kez çağrıldı, beklenen 2 (başarısız işleme yeniden bağlanılmamalı)", recognizer.submits)
+	}
+}
+
+// iptalde işlem sunucuda sürer; kayıt korunmalı ki resume aynı işleme bağlansın
+func TestRecognizeKeepsOperationOnCancel(t *testing.T) {
+	p, job := newOperationTestJob(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Recognizer = &failingAsyncRecognizer{onResume: cancel}
+
+	if _, err := p.recognize(ctx, job, &models.AudioMetadata{}, p.Config); !errors.Is(err, context.Canceled) {
+		t.Fatalf("iptal hatası bekleniyordu: %v", err)
+	}
+
+	saved, err := p.Jobs.Load(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.OperationName != "operations/1" || saved.Stage != jobs.StageSubmitted {
+		t.Errorf("kayıt: işlem %q, aşama %s; beklenen operations/1, %s", saved.OperationName, saved.Stage, jobs.StageSubmitted)
+	}
+}
@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"spt2/internal/jobs"
+	"spt2/internal/storage"
+	"spt2/pkg/models"
+)
+
+// aynı adlı iki kaynağın FLAC'leri ve obje adları ayrışmalı
+func TestJobFLACPathUnique(t *testing.T) {
+	store := jobs.NewStore(t.TempDir())
+	cfg := &models.AppConfig{OutputDir: t.TempDir()}
+
+	first, err := store.Create("a/ders.wav", cfg.OutputDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := store.Create("b/ders.mp3", cfg.OutputDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstPath, secondPath := jobFLACPath(first, cfg), jobFLACPath(second, cfg)
+	if firstPath == secondPath {
+		t.Fatalf("FLAC yolları aynı: %s", firstPath)
+	}
+	if filepath.Dir(firstPath) != cfg.OutputDir || !strings.HasSuffix(firstPath, first.ID+".flac") {
+		t.Errorf("FLAC yolu = %s, beklenen %s/%s.flac", firstPath, cfg.OutputDir, first.ID)
+	}
+	if storage.ObjectName(firstPath) == storage.ObjectName(secondPath) {
+		t.Errorf("obje adları aynı: %s", storage.ObjectName(firstPath))
+	}
+	if _, name, ok := storage.ParseObjectName(storage.ObjectName(secondPath)); !ok || name != second.ID+".flac" {
+		t.Errorf("obje adı çözülemedi: %s", name)
+	}
+
+	if path := jobFLACPath(&jobs.Manifest{}, cfg); path != "" {
+		t.Errorf("iş kaydı yokken yol boş olmalı: %s", path)
+	}
+}
@@ -0,0 +1,762 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"spt2/internal/analysis"
+	"spt2/internal/audio"
+	"spt2/internal/cache"
+	"spt2/internal/jobs"
+	"spt2/internal/output"
+	"spt2/internal/speechclient"
+	"spt2/internal/storage"
+	"spt2/pkg/models"
+)
+
+// Pipeline - tek bir ses dosyası için uçtan uca deşifre akışı
+//
+// ADIMLAR:
+//  1. Metadata çıkarma ve validasyon
+//  2. FLAC'e dönüştürme
+//  3. ObjectStore'a (GCS, yerel dizin veya bellek) yükleme
+//     (kısa dosyalarda atlanır, bkz. speechclient.UseSyncRecognition)
+//  4. Recognizer ile deşifre
+//     (chunk_max_duration'dan uzun sesler sessizliklerden parçalara bölünür;
+//     parçalar ayrı ayrı yüklenip paralel tanınır ve birleştirilir, bkz. recognizeChunks)
+//  5. Çıktılar (JSON, SRT, TXT, VTT; generate_* ayarlarına göre)
+//
+// Her adımdan sonra iş kaydı (jobs.Manifest) güncellenir; yarıda kalan bir
+// iş Resume ile tamamlanan adımlar atlanarak sürdürülür. Aynı ses aynı
+// tanıma ayarlarıyla daha önce deşifre edildiyse sonuç cache'ten alınır ve
+// doğrudan 5. adıma geçilir.
+//
+// Recognizer ve Store dışarıdan verilir; böylece aynı akış Google yerine
+// fake backend ve memory storage ile kimlik bilgisi olmadan da çalıştırılabilir.
+type Pipeline struct {
+	Config     *models.AppConfig
+	Recognizer speechclient.Recognizer
+	Store      storage.ObjectStore
+	Jobs       *jobs.Store  // iş kayıtları (nil ise kayıt tutulmaz ve resume yapılamaz)
+	Cache      *cache.Cache // sonuç cache'i (nil ise kapalı)
+	Out        io.Writer    // ilerleme mesajları buraya yazılır
+}
+
+// Result - bir pipeline çalışmasının ürettikleri
+type Result struct {
+	Metadata      *models.AudioMetadata
+	Transcription *models.TranscriptionResult
+	OutputFiles   []string
+}
+
+// yeni pipeline oluşturma (ilerleme mesajları stdout'a, iş kayıtları
+// <output_dir>/jobs altına yazılır; enable_cache açıksa cache_dir kullanılır)
+func New(cfg *models.AppConfig, recognizer speechclient.Recognizer, store storage.ObjectStore) *Pipeline {
+	p := &Pipeline{
+		Config:     cfg,
+		Recognizer: recognizer,
+		Store:      store,
+		Jobs:       jobs.NewStore(filepath.Join(cfg.OutputDir, "jobs")),
+		Out:        os.Stdout,
+	}
+	if cfg.EnableCache {
+		p.Cache = cache.New(cfg.CacheDir)
+	}
+	return p
+}
+
+func (p *Pipeline) logf(format string, args ...any) {
+	if p.Out != nil {
+		fmt.Fprintf(p.Out, format, args...)
+	}
+}
+
+// Run - verilen ses dosyası için yeni bir iş kaydı açar ve tüm adımları sırayla çalıştırır
+func (p *Pipeline) Run(ctx context.Context, audioFilePath string) (*Result, error) {
+	job := &jobs.Manifest{AudioFile: audioFilePath, OutputDir: p.Config.OutputDir, Stage: jobs.StageCreated}
+	if p.Jobs != nil {
+		var err error
+		job, err = p.Jobs.Create(audioFilePath, p.Config.OutputDir)
+		if err != nil {
+			return nil, fmt.Errorf("iş kaydı açılamadı: %w", err)
+		}
+		p.logf("🗂️  İş kaydı: %s\n\n", job.ID)
+	}
+
+	return p.runJob(ctx, job)
+}
+
+// Resume - yarıda kalmış bir işi, tamamlanan aşamaları atlayarak sürdürür
+func (p *Pipeline) Resume(ctx context.Context, jobID string) (*Result, error) {
+	if p.Jobs == nil {
+		return nil, fmt.Errorf("iş kayıtları kapalı, devam ettirilemez")
+	}
+
+	job, err := p.Jobs.Load(jobID)
+	if err != nil {
+		return nil, err
+	}
+	p.logf("♻️  İş devam ettiriliyor: %s (%s, son aşama: %s)\n\n", job.ID, job.AudioFile, job.Stage)
+
+	return p.runJob(ctx, job)
+}
+
+// hata durumunda da manifest'e son hatayı yazma
+func (p *Pipeline) runJob(ctx context.Context, job *jobs.Manifest) (*Result, error) {
+	result, err := p.runStages(ctx, job)
+	if err != nil {
+		job.Error = err.Error()
+		if saveErr := p.saveJob(job); saveErr != nil {
+			p.logf("⚠️  İş kaydı güncellenemedi: %v\n", saveErr)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *Pipeline) saveJob(job *jobs.Manifest) error {
+	if p.Jobs == nil {
+		return nil
+	}
+	return p.Jobs.Save(job)
+}
+
+// işi kaldığı aşamadan bitirme
+func (p *Pipeline) runStages(ctx context.Context, job *jobs.Manifest) (*Result, error) {
+	// iş hangi output dizininde başladıysa orada biter
+	cfg := p.Config
+	if job.OutputDir != "" && job.OutputDir != cfg.OutputDir {
+		jobCfg := *cfg
+		jobCfg.OutputDir = job.OutputDir
+		cfg = &jobCfg
+	}
+
+	var result *models.TranscriptionResult
+	if p.Cache != nil && !job.Stage.Reached(jobs.StageConverted) {
+		entry, err := p.lookupCache(job, cfg)
+		if err != nil {
+			p.logf("⚠️  Cache okunamadı: %v\n\n", err)
+		}
+		if entry != nil {
+			p.logf("💾 Sonuç cache'te bulundu (%s), dönüştürme, yükleme ve deşifre atlanıyor\n\n", cache.ShortKey(entry.Key))
+			result = entry.Result
+			job.Metadata = entry.Metadata
+			job.CacheHit = true
+			if p.Jobs != nil {
+				if err := p.Jobs.SaveResult(job, result); err != nil {
+					return nil, err
+				}
+			}
+			job.Stage = jobs.StageRecognized
+			if err := p.saveJob(job); err != nil {
+				return nil, fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+			}
+		}
+	}
+
+	// FLAC, işlem başlatılana kadar gerekir; silinmişse yeniden üretilir
+	needsFLAC := !job.Stage.Reached(jobs.StageSubmitted) && !fileExists(job.FLACPath)
+	if !job.Stage.Reached(jobs.StageConverted) || needsFLAC {
+		metadata, err := p.prepare(job.AudioFile, jobFLACPath(job, cfg), cfg)
+		if err != nil {
+			return nil, err
+		}
+		job.Metadata = metadata
+		job.FLACPath = metadata.ConvertedPath
+		job.Stage = jobs.StageConverted
+		if err := p.saveJob(job); err != nil {
+			return nil, fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+		}
+	} else if !job.CacheHit {
+		p.logf("⏭️  Dönüştürme atlanıyor, mevcut FLAC kullanılacak: %s\n\n", job.FLACPath)
+	}
+	metadata := job.Metadata
+
+	// parça planı bir kez çıkarılır; resume aynı parçalarla devam eder
+	if !job.Stage.Reached(jobs.StageRecognized) && len(job.Chunks) == 0 && audio.ShouldChunk(metadata, cfg) {
+		if err := p.split(job, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if !job.Stage.Reached(jobs.StageUploaded) {
+		if err := p.upload(ctx, job, cfg); err != nil {
+			return nil, err
+		}
+		job.Stage = jobs.StageUploaded
+		if err := p.saveJob(job); err != nil {
+			return nil, fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+		}
+	}
+
+	switch {
+	case result != nil:
+		// sonuç cache'ten geldi
+	case job.Stage.Reached(jobs.StageRecognized):
+		var err error
+		result, err = p.Jobs.LoadResult(job)
+		if err != nil {
+			return nil, err
+		}
+		p.logf("⏭️  Deşifre atlanıyor, kayıtlı sonuç kullanılacak: %s\n\n", job.ResultPath)
+	default:
+		var err error
+		result, err = p.recognizeStage(ctx, job, metadata, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if metadata.HasVideo {
+		result.FrameRate = metadata.FrameRate
+		result.VideoDuration = metadata.VideoDuration
+	}
+	analysis.Annotate(result, cfg)
+
+	if !job.Stage.Reached(jobs.StageExported) {
+		outputFiles, err := p.export(result, job.AudioFile, cfg)
+		if err != nil {
+			return nil, err
+		}
+		job.OutputFiles = outputFiles
+		job.Stage = jobs.StageExported
+		job.Error = ""
+		if err := p.saveJob(job); err != nil {
+			return nil, fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+		}
+	} else {
+		p.logf("⏭️  İş zaten tamamlanmış, çıktılar: %v\n", job.OutputFiles)
+	}
+
+	return &Result{
+		Metadata:      metadata,
+		Transcription: result,
+		OutputFiles:   job.OutputFiles,
+	}, nil
+}
+
+// metadata çıkarma, validasyon, ses izi seçimi ve FLAC dönüşümü
+func (p *Pipeline) prepare(audioFilePath string, flacPath string, cfg *models.AppConfig) (*models.AudioMetadata, error) {
+	p.logf("🎵 Ses dosyası metadata'sı çıkarılıyor...\n")
+	metadata, err := audio.ExtractMetadata(audioFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("metadata çıkarılamadı: %w", err)
+	}
+	p.logf("✅ Metadata çıkarıldı (Format: %s, Codec: %s, Süre: %.1f sn, %d Hz, %d kanal, Boyut: %d bytes)\n\n",
+		metadata.OriginalFormat, metadata.Codec, metadata.Duration, metadata.SampleRate, metadata.Channels, metadata.FileSize)
+	if metadata.FormatWarning != "" {
+		p.logf("⚠️  %s\n\n", metadata.FormatWarning)
+	}
+	if metadata.HasVideo {
+		p.logf("🎬 Video dosyası (Codec: %s, %.2f fps, Süre: %.1f sn), ses izi çıkarılacak\n\n",
+			metadata.VideoCodec, metadata.FrameRate, metadata.VideoDuration)
+	}
+
+	if err := audio.SelectAudioTrack(metadata, cfg.AudioTrack, cfg.AudioLanguage); err != nil {
+		return nil, fmt.Errorf("ses izi seçilemedi: %w", err)
+	}
+	if len(metadata.AudioTracks) > 1 {
+		track := metadata.AudioTracks[metadata.SelectedTrack]
+		p.logf("🔊 %d ses izinden %d. seçildi (Dil: %s, Codec: %s, %d kanal)\n\n",
+			len(metadata.AudioTracks), track.Index, track.Language, track.Codec, track.Channels)
+	}
+
+	//validate etme
+	p.logf("✔️  Ses dosyası validate ediliyor...\n")
+	if err := audio.ValidateMetadata(metadata, cfg); err != nil {
+		return nil, fmt.Errorf("validasyon hatası: %w", err)
+	}
+	p.logf("✅ Validasyon başarılı\n\n")
+
+	if cfg.EnableVAD {
+		if err := p.detectSpeech(metadata, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	//flac
+	p.logf("🔄 Ses dosyası FLAC formatına dönüştürülüyor...\n")
+	if err := audio.ConvertToFLAC(metadata, cfg, flacPath); err != nil {
+		return nil, fmt.Errorf("FLAC dönüştürme hatası: %w", err)
+	}
+	p.logf("✅ FLAC'e dönüştürüldü: %s (%d Hz, %d kanal)\n\n", metadata.ConvertedPath, metadata.ConvertedSampleRate, metadata.ConvertedChannels)
+	if metadata.FilterGraph != "" {
+		p.logf("🎚️  Ön işleme uygulandı: %s\n\n", metadata.FilterGraph)
+	}
+	if cfg.MultiChannel && metadata.ConvertedChannels < 2 {
+		p.logf("⚠️  multi_channel açık ama dosya tek kanallı; kanal bazında tanıma yapılmayacak\n\n")
+	}
+
+	return metadata, nil
+}
+
+// konuşma bölgelerini bulma; ses sessizse dönüştürme ve (ücretli) tanıma yapılmaz
+//
+// Bölgeler metadata'ya yazılır: parça sınırları bu bölgelerin arasından
+// seçilir, trim_silence açıksa baştaki ve sondaki sessizlik FLAC'e alınmaz.
+func (p *Pipeline) detectSpeech(metadata *models.AudioMetadata, cfg *models.AppConfig) error {
+	p.logf("🗣️  Konuşma bölgeleri tespit ediliyor...\n")
+	vad, err := audio.DetectSpeech(metadata, audio.VADOptionsFromConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("konuşma tespiti hatası: %w", err)
+	}
+	if vad.Silent() {
+		return fmt.Errorf("konuşma tespit edilemedi (%.1f sn ses, gürültü tabanı %.1f dBFS, tepe %.1f dBFS); dosya deşifreye gönderilmedi", vad.Duration, vad.NoiseFloor, vad.PeakEnergy)
+	}
+	if len(vad.Regions) == 0 {
+		// ses yüksek ama konuşma gürültüden ayrılamadı; tamamı tanımaya gider
+		p.logf("⚠️  Konuşma bölgeleri ayrılamadı (tepe %.1f dBFS); sesin tamamı deşifre edilecek\n\n", vad.PeakEnergy)
+		return nil
+	}
+
+	metadata.SpeechRegions = vad.Regions
+	metadata.SpeechDuration = vad.SpeechDuration
+	metadata.SpeechRatio = vad.SpeechRatio()
+	p.logf("✅ Konuşma oranı: %%%.0f (%.1f / %.1f sn, %d bölge)\n\n",
+		metadata.SpeechRatio*100, vad.SpeechDuration, vad.Duration, len(vad.Regions))
+
+	if cfg.TrimSilence {
+		start, end, _ := audio.SpeechBounds(vad.Regions)
+		if start > 0 || end < vad.Duration {
+			metadata.TrimStart = start
+			metadata.TrimEnd = end
+			p.logf("✂️  Baştaki %.1f sn ve sondaki %.1f sn sessizlik kırpılacak\n\n", start, vad.Duration-end)
+		}
+	}
+	return nil
+}
+
+// sessizlikleri bulup parça planını manifest'e yazma
+//
+// Konuşma tespiti yapıldıysa sessizlikler konuşma bölgelerinin
+// arasıdır, yapılmadıysa FLAC'te ffmpeg silencedetect ile aranır.
+// Parça dosyaları burada değil, her parça tanınmadan hemen önce kesilir
+// (bkz. recognizeChunk); böylece diskte aynı anda en fazla chunk_workers
+// kadar parça bulunur.
+func (p *Pipeline) split(job *jobs.Manifest, cfg *models.AppConfig) error {
+	metadata := job.Metadata
+	duration := audio.ConvertedDuration(metadata)
+	p.logf("✂️  Uzun ses (%.1f sn) parçalara bölünüyor...\n", duration)
+
+	var silences []audio.Silence
+	if len(metadata.SpeechRegions) > 0 {
+		silences = audio.RegionSilences(metadata.SpeechRegions, metadata.TrimStart, metadata.TrimStart+duration)
+	} else {
+		var err error
+		silences, err = audio.DetectSilences(job.FLACPath, duration, cfg.SilenceThreshold, cfg.SilenceMinDuration)
+		if err != nil {
+			return fmt.Errorf("ses parçalara bölünemedi: %w", err)
+		}
+	}
+
+	planned := audio.PlanChunks(duration, silences, cfg.ChunkMaxDuration, cfg.ChunkOverlap)
+	job.Chunks = make([]jobs.Chunk, len(planned))
+	for i, chunk := range planned {
+		chunk.Path = audio.ChunkPath(job.FLACPath, chunk.Index)
+		job.Chunks[i] = jobs.Chunk{Chunk: chunk}
+	}
+	if err := p.saveJob(job); err != nil {
+		return fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+	}
+
+	p.logf("✅ %d sessizlik bulundu, ses %d parçaya bölündü (en fazla %.0f sn, %.1f sn örtüşme)\n\n",
+		len(silences), len(job.Chunks), cfg.ChunkMaxDuration, cfg.ChunkOverlap)
+	return nil
+}
+
+// FLAC'i depolamaya yükleme; kısa dosyalar yüklenmeden senkron tanınır (AudioURI boş kalır)
+// parçalara bölünen seslerde her parça kendi tanımasından önce yüklenir
+func (p *Pipeline) upload(ctx context.Context, job *jobs.Manifest, cfg *models.AppConfig) error {
+	metadata := job.Metadata
+	if len(job.Chunks) > 0 {
+		p.logf("⏭️  Tüm dosyanın yüklenmesi atlanıyor, %d parça ayrı ayrı yüklenecek\n\n", len(job.Chunks))
+		return nil
+	}
+	if speechclient.UseSyncRecognition(metadata, cfg) {
+		job.Sync = true
+		p.logf("⚡ Kısa ses dosyası (%.1f sn): senkron tanıma kullanılacak, yükleme atlanıyor\n\n", metadata.Duration)
+		return nil
+	}
+
+	p.logf("☁️  FLAC dosyası depolamaya yükleniyor (storage: %s)...\n", cfg.StorageBackend)
+	objectName := storage.ObjectName(metadata.ConvertedPath)
+	objectMetadata := storage.RetentionMetadata(cfg, job.AudioFile, time.Now())
+	audioURI, err := p.Store.Put(ctx, metadata.ConvertedPath, objectName, objectMetadata)
+	if err != nil {
+		return fmt.Errorf("yükleme hatası: %w", err)
+	}
+	job.ObjectName = objectName
+	job.AudioURI = audioURI
+	p.logf("✅ Dosya yüklendi: %s\n\n", audioURI)
+
+	return nil
+}
+
+// deşifre, saklama politikası, cache ve sonucun iş kaydına yazılması
+func (p *Pipeline) recognizeStage(ctx context.Context, job *jobs.Manifest, metadata *models.AudioMetadata, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	p.logf("🎤 Ses dosyası deşifre ediliyor (bu birkaç dakika sürebilir)...\n")
+	var result *models.TranscriptionResult
+	var err error
+	if len(job.Chunks) > 0 {
+		result, err = p.recognizeChunks(ctx, job, metadata, cfg)
+	} else {
+		result, err = p.recognize(ctx, job, metadata, cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("deşifre hatası: %w", err)
+	}
+	// kırpılmış FLAC'in zamanları kaynak sese taşınır
+	if metadata.TrimStart > 0 {
+		speechclient.OffsetResult(result, metadata.TrimStart)
+	}
+	p.logf("✅ Deşifre tamamlandı (%d karakter)\n\n", len(result.Transcript))
+
+	// obje yalnızca başarılı deşifreden sonra silinir; hata durumunda
+	// resume aynı objeyi tekrar gönderebilir (kalanlar `spt2 gc` ile temizlenir)
+	if job.ObjectName != "" {
+		p.applyRetention(ctx, job.ObjectName)
+	}
+
+	if p.Cache != nil {
+		if err := p.storeCache(job, metadata, result, cfg); err != nil {
+			p.logf("⚠️  Sonuç cache'e yazılamadı: %v\n\n", err)
+		}
+	}
+
+	if p.Jobs != nil {
+		if err := p.Jobs.SaveResult(job, result); err != nil {
+			return nil, err
+		}
+	}
+	job.Stage = jobs.StageRecognized
+	if err := p.saveJob(job); err != nil {
+		return nil, fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+	}
+
+	return result, nil
+}
+
+// deşifre; motor AsyncRecognizer ise işlem adı önce manifest'e yazılır,
+// böylece Wait sırasında süreç ölse bile resume aynı işleme bağlanabilir.
+// İşlem başarısız olursa iş "uploaded" aşamasına döner.
+func (p *Pipeline) recognize(ctx context.Context, job *jobs.Manifest, metadata *models.AudioMetadata, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	return p.recognizeAudio(ctx, metadata, job.AudioURI, job.OperationName, func(operationName string) error {
+		job.OperationName = operationName
+		job.Stage = jobs.StageSubmitted
+		if operationName == "" {
+			job.Stage = jobs.StageUploaded
+		}
+		return p.saveJob(job)
+	}, cfg)
+}
+
+// tek bir FLAC'in (tüm dosya veya parça) deşifresi
+//
+// operationName önceki çalışmada başlatılmış işlemdir (yoksa boş); yeni
+// işlem başlatılınca adı saveOperation ile kaydedilir. İşlem iptal dışı bir
+// hatayla biterse saveOperation("") ile kayıt silinir: resume başarısız
+// işleme tekrar bağlanmak yerine yeni işlem başlatır. İptalde (ctx) kayıt
+// korunur, işlem sunucuda sürmeye devam eder.
+func (p *Pipeline) recognizeAudio(ctx context.Context, metadata *models.AudioMetadata, audioURI string, operationName string, saveOperation func(operationName string) error, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	async, ok := p.Recognizer.(speechclient.AsyncRecognizer)
+	if !ok || audioURI == "" {
+		if operationName != "" {
+			return nil, fmt.Errorf("işlem %s devam ettirilemiyor: %s backend'i long-running işlemleri desteklemiyor", operationName, cfg.Backend)
+		}
+		return p.Recognizer.Recognize(ctx, metadata, audioURI, cfg)
+	}
+
+	if operationName == "" {
+		var err error
+		operationName, err = async.Submit(ctx, metadata, audioURI, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveOperation(operationName); err != nil {
+			return nil, fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+		}
+		p.logf("📨 Tanıma işlemi başlatıldı: %s\n", operationName)
+	} else {
+		p.logf("🔗 Mevcut tanıma işlemine bağlanılıyor: %s\n", operationName)
+	}
+
+	result, err := async.Resume(ctx, operationName, metadata, cfg)
+	if err != nil && !isContextError(ctx, err) {
+		if saveErr := saveOperation(""); saveErr != nil {
+			p.logf("⚠️  Başarısız işlem iş kaydından silinemedi: %v\n", saveErr)
+		} else {
+			p.logf("⚠️  Tanıma işlemi başarısız, resume yeni işlem başlatacak: %s\n", operationName)
+		}
+		return nil, err
+	}
+	return result, err
+}
+
+// hata iptal veya zaman aşımından mı (işlem sunucuda sürüyor olabilir)
+func isContextError(ctx context.Context, err error) bool {
+	return ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// parçaları en fazla chunk_workers eşzamanlı iş ile tanıyıp birleştirme
+//
+// Her parçanın sonucu ayrı kaydedilir; bir parça hata verirse başlamamış
+// parçalar beklemeden iptal edilir ve resume yalnızca eksik parçaları tanır.
+// Birleştirmeden sonra parça dosyaları silinir.
+func (p *Pipeline) recognizeChunks(ctx context.Context, job *jobs.Manifest, metadata *models.AudioMetadata, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := cfg.ChunkWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]*models.TranscriptionResult, len(job.Chunks))
+	indexes := make(chan int)
+	var mu sync.Mutex // manifest'e yazma ve ilk hata
+	var wg sync.WaitGroup
+	var firstErr error
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result, err := p.recognizeChunk(ctx, job, i, metadata, cfg, &mu)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("%d. parça: %w", i+1, err)
+					cancel()
+				}
+				results[i] = result
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for i := range job.Chunks {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	chunks := make([]audio.Chunk, len(job.Chunks))
+	for i := range job.Chunks {
+		chunks[i] = job.Chunks[i].Chunk
+	}
+	result := speechclient.StitchResults(metadata, chunks, results)
+	p.logf("🧵 %d parçanın sonucu birleştirildi\n", len(chunks))
+
+	for _, chunk := range chunks {
+		os.Remove(chunk.Path)
+	}
+	return result, nil
+}
+
+// tek parçanın kesilmesi, yüklenmesi ve tanınması
+//
+// Sonucu kaydedilmiş parça tekrar tanınmaz; işlemi başlatılmış parça aynı
+// işleme bağlanır. Parça dosyası yalnızca henüz gönderilmemişse (yeniden) kesilir.
+func (p *Pipeline) recognizeChunk(ctx context.Context, job *jobs.Manifest, index int, metadata *models.AudioMetadata, cfg *models.AppConfig, mu *sync.Mutex) (*models.TranscriptionResult, error) {
+	mu.Lock()
+	chunk := job.Chunks[index]
+	mu.Unlock()
+	total := len(job.Chunks)
+
+	if chunk.Done() {
+		p.logf("⏭️  %d/%d. parça atlanıyor, kayıtlı sonuç kullanılacak\n", index+1, total)
+		return p.Jobs.LoadChunkResult(&chunk)
+	}
+
+	// saveChunk - parçanın durumunu manifest'e yazma
+	saveChunk := func(update func(c *jobs.Chunk)) error {
+		mu.Lock()
+		defer mu.Unlock()
+		update(&job.Chunks[index])
+		chunk = job.Chunks[index]
+		return p.saveJob(job)
+	}
+
+	chunkMetadata := *metadata
+	chunkMetadata.ConvertedPath = chunk.Path
+	chunkMetadata.Duration = chunk.Duration()
+	chunkMetadata.ConvertedDuration = chunk.Duration()
+
+	if chunk.OperationName == "" && chunk.AudioURI == "" && !fileExists(chunk.Path) {
+		if err := audio.ExtractChunk(job.FLACPath, chunk.Chunk); err != nil {
+			return nil, err
+		}
+	}
+
+	if chunk.AudioURI == "" && !chunk.Sync {
+		if speechclient.UseSyncRecognition(&chunkMetadata, cfg) {
+			if err := saveChunk(func(c *jobs.Chunk) { c.Sync = true }); err != nil {
+				return nil, fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+			}
+		} else {
+			objectName := storage.ObjectName(chunk.Path)
+			objectMetadata := storage.RetentionMetadata(cfg, job.AudioFile, time.Now())
+			audioURI, err := p.Store.Put(ctx, chunk.Path, objectName, objectMetadata)
+			if err != nil {
+				return nil, fmt.Errorf("yükleme hatası: %w", err)
+			}
+			if err := saveChunk(func(c *jobs.Chunk) { c.ObjectName, c.AudioURI = objectName, audioURI }); err != nil {
+				return nil, fmt.Errorf("iş kaydı güncellenemedi: %w", err)
+			}
+		}
+	}
+
+	p.logf("🎤 %d/%d. parça deşifre ediliyor (%s - %s)\n", index+1, total, output.FormatClock(chunk.Start), output.FormatClock(chunk.End))
+	result, err := p.recognizeAudio(ctx, &chunkMetadata, chunk.AudioURI, chunk.OperationName, func(operationName string) error {
+		return saveChunk(func(c *jobs.Chunk) { c.OperationName = operationName })
+	}, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if chunk.ObjectName != "" {
+		p.applyRetention(ctx, chunk.ObjectName)
+	}
+
+	if p.Jobs != nil {
+		mu.Lock()
+		err := p.Jobs.SaveChunkResult(job, &job.Chunks[index], result)
+		if err == nil {
+			err = p.saveJob(job)
+		}
+		mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+	p.logf("✅ %d/%d. parça tamamlandı (%d kelime)\n", index+1, total, len(result.Words))
+
+	return result, nil
+}
+
+// ses içeriği ve tanıma ayarlarıyla cache'e bakma (anahtar manifest'e yazılır)
+func (p *Pipeline) lookupCache(job *jobs.Manifest, cfg *models.AppConfig) (*cache.Entry, error) {
+	if job.CacheKey == "" {
+		contentHash, err := cache.HashFile(job.AudioFile)
+		if err != nil {
+			return nil, err
+		}
+		job.ContentHash = contentHash
+		job.CacheKey = cache.Key(contentHash, cache.SettingsFor(cfg))
+	}
+
+	entry, err := p.Cache.Get(job.CacheKey)
+	if err != nil || entry == nil || entry.Result == nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// başarılı deşifre sonucunu cache'e yazma
+func (p *Pipeline) storeCache(job *jobs.Manifest, metadata *models.AudioMetadata, result *models.TranscriptionResult, cfg *models.AppConfig) error {
+	if job.CacheKey == "" {
+		if _, err := p.lookupCache(job, cfg); err != nil {
+			return err
+		}
+	}
+
+	return p.Cache.Put(&cache.Entry{
+		Key:         job.CacheKey,
+		ContentHash: job.ContentHash,
+		AudioFile:   job.AudioFile,
+		AudioSize:   metadata.FileSize,
+		Settings:    cache.SettingsFor(cfg),
+		CreatedAt:   time.Now(),
+		Metadata:    metadata,
+		Result:      result,
+	})
+}
+
+// işin FLAC yolu: <output_dir>/<iş_id>.flac
+//
+// NEDEN: aynı adlı iki kaynak (örn. a/ders.wav ve b/ders.mp3) aynı output_dir'e
+// yazıldığında FLAC'ler ve yüklenen objeler birbirini ezmesin. İş kaydı
+// yoksa ID de yoktur; ConvertToFLAC kaynak adını kullanır.
+func jobFLACPath(job *jobs.Manifest, cfg *models.AppConfig) string {
+	if job.ID == "" {
+		return ""
+	}
+	return filepath.Join(cfg.OutputDir, job.ID+".flac")
+}
+
+func fileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// "delete" politikasında yüklenen objeyi deşifreden hemen sonra silme
+//
+// Silme hatası akışı durdurmaz; obje daha sonra `spt2 gc` ile temizlenebilir.
+func (p *Pipeline) applyRetention(ctx context.Context, objectName string) {
+	if p.Config.RetentionPolicy != storage.RetentionDelete {
+		return
+	}
+
+	if err := p.Store.Delete(ctx, objectName); err != nil {
+		p.logf("⚠️  Yüklenen obje silinemedi (spt2 gc ile temizlenebilir): %v\n\n", err)
+		return
+	}
+	p.logf("🗑️  Yüklenen obje silindi: %s\n\n", objectName)
+}
+
+// config'te açık olan (generate_*) çıktı formatlarını üretme
+func (p *Pipeline) export(result *models.TranscriptionResult, audioFilePath string, cfg *models.AppConfig) ([]string, error) {
+	formats := output.EnabledFormats(cfg)
+	if len(formats) == 0 {
+		p.logf("⚠️  Hiçbir çıktı formatı açık değil (generate_json, generate_srt, generate_txt, generate_vtt)\n")
+		return nil, nil
+	}
+
+	return ExportFormats(result, audioFilePath, cfg, formats, p.logf)
+}
+
+// ExportFormats - sonucu verilen formatlarda cfg.OutputDir altına yazar
+//
+// logf nil olabilir. export komutu da (kaydedilmiş JSON'dan yeniden üretim)
+// aynı fonksiyonu kullanır.
+func ExportFormats(result *models.TranscriptionResult, audioFilePath string, cfg *models.AppConfig, formats []*output.Format, logf func(format string, args ...any)) ([]string, error) {
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+
+	var outputFiles []string
+	for _, format := range formats {
+		logf("📝 %s dosyası oluşturuluyor...\n", format.Label)
+		path, err := format.Export(result, audioFilePath, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s kaydetme hatası: %w", strings.ToUpper(format.Name), err)
+		}
+		logf("✅ %s dosyası oluşturuldu: %s\n\n", strings.ToUpper(format.Name), path)
+		outputFiles = append(outputFiles, path)
+	}
+
+	return outputFiles, nil
+}
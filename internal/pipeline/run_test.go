@@ -0,0 +1,268 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"spt2/internal/config"
+	"spt2/internal/jobs"
+	"spt2/internal/speechclient"
+	"spt2/internal/storage"
+	"spt2/pkg/models"
+)
+
+// ffmpeg yerine geçen betik: son argümana (çıktı yolu) hazır FLAC'i kopyalar
+// ve her çağrıyı FAKE_FFMPEG_LOG'a bir satır olarak yazar
+const fakeFFmpegScript = `#!/bin/sh
+for last; do :; done
+echo "$*" >> "$FAKE_FFMPEG_LOG"
+cp "$FAKE_FLAC" "$last"
+`
+
+// FakeRecognizer'ı sayan, istenirse ilk çağrıda hata veren motor
+type countingRecognizer struct {
+	speechclient.Recognizer
+	calls     int
+	failFirst error
+}
+
+func (r *countingRecognizer) Recognize(ctx context.Context, metadata *models.AudioMetadata, audioURI string, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	r.calls++
+	if r.calls == 1 && r.failFirst != nil {
+		return nil, r.failFirst
+	}
+	return r.Recognizer.Recognize(ctx, metadata, audioURI, cfg)
+}
+
+type fakeEnv struct {
+	cfg       *models.AppConfig
+	audioFile string
+	ffmpegLog string
+}
+
+// ffmpeg sayısı
+func (env *fakeEnv) conversions(t *testing.T) int {
+	t.Helper()
+	data, err := os.ReadFile(env.ffmpegLog)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Count(string(data), "\n")
+}
+
+// fake backend, credentials ve GCS olmadan yüklenen config; PATH'te sahte ffmpeg
+//
+// cacheDir boşsa cache kapatılır.
+func newFakeEnv(t *testing.T, cacheDir string) *fakeEnv {
+	t.Helper()
+	dir := t.TempDir()
+
+	binDir := filepath.Join(dir, "bin")
+	if err := os.Mkdir(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "ffmpeg"), []byte(fakeFFmpegScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	flacPath := filepath.Join(dir, "template.flac")
+	writeFLACHeader(t, flacPath, 16000, 2)
+
+	env := &fakeEnv{ffmpegLog: filepath.Join(dir, "ffmpeg.log")}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_FLAC", flacPath)
+	t.Setenv("FAKE_FFMPEG_LOG", env.ffmpegLog)
+
+	configPath := filepath.Join(dir, "config.json")
+	configJSON := fmt.Sprintf(`{
+		"backend": "fake",
+		"language_code": "en-US",
+		"model": "default",
+		"enable_automatic_punctuation": true,
+		"enable_word_time_offsets": true,
+		"generate_json": true,
+		"generate_srt": true,
+		"generate_txt": true,
+		"enable_cache": %t,
+		"cache_dir": %q,
+		"output_dir": %q
+	}`, cacheDir != "", cacheDir, filepath.Join(dir, "output"))
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("config: %v", err)
+	}
+	if cfg.StorageBackend != "memory" {
+		t.Fatalf("fake backend'te storage_backend = %s, beklenen memory", cfg.StorageBackend)
+	}
+	env.cfg = cfg
+
+	env.audioFile = filepath.Join(dir, "api_lecture_notes.wav")
+	writeSilentWAV(t, env.audioFile, 16000, 2)
+	return env
+}
+
+func (env *fakeEnv) pipeline(t *testing.T, recognizer speechclient.Recognizer) *Pipeline {
+	t.Helper()
+	p := New(env.cfg, recognizer, storage.NewMemoryStore("spt2"))
+	p.Out = nil
+	return p
+}
+
+func newCountingRecognizer(t *testing.T, failFirst error) *countingRecognizer {
+	t.Helper()
+	fake, err := speechclient.NewFakeRecognizer("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &countingRecognizer{Recognizer: fake, failFirst: failFirst}
+}
+
+// fLaC + STREAMINFO (16 bit)
+func writeFLACHeader(t *testing.T, path string, sampleRate int, seconds int) {
+	t.Helper()
+	streamInfo := make([]byte, 34)
+	streamInfo[10] = byte(sampleRate >> 12)
+	streamInfo[11] = byte(sampleRate >> 4)
+	streamInfo[12] = byte(sampleRate << 4)
+	streamInfo[13] = byte(15 << 4)
+	binary.BigEndian.PutUint32(streamInfo[14:18], uint32(sampleRate*seconds))
+
+	data := append([]byte("fLaC"), 0x80, 0, 0, 34)
+	if err := os.WriteFile(path, append(data, streamInfo...), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sessiz 16 bit mono PCM WAV
+func writeSilentWAV(t *testing.T, path string, sampleRate int, seconds int) {
+	t.Helper()
+	dataSize := sampleRate * seconds * 2
+
+	var buf []byte
+	buf = append(buf, "RIFF"...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(36+dataSize))
+	buf = append(buf, "WAVEfmt "...)
+	buf = binary.LittleEndian.AppendUint32(buf, 16)
+	buf = binary.LittleEndian.AppendUint16(buf, 1)
+	buf = binary.LittleEndian.AppendUint16(buf, 1)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(sampleRate))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(sampleRate*2))
+	buf = binary.LittleEndian.AppendUint16(buf, 2)
+	buf = binary.LittleEndian.AppendUint16(buf, 16)
+	buf = append(buf, "data"...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(dataSize))
+	buf = append(buf, make([]byte, dataSize)...)
+
+	if err := os.WriteFile(path, buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// dönüştürme → tanıma → çıktılar, GCP hesabı olmadan
+func TestRunWithFakeBackend(t *testing.T) {
+	env := newFakeEnv(t, "")
+	recognizer := newCountingRecognizer(t, nil)
+
+	result, err := env.pipeline(t, recognizer).Run(context.Background(), env.audioFile)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result.Transcription.Transcript != "api lecture notes" {
+		t.Errorf("transcript = %q", result.Transcription.Transcript)
+	}
+	if len(result.Transcription.Words) != 3 {
+		t.Errorf("%d kelime, beklenen 3", len(result.Transcription.Words))
+	}
+	if env.conversions(t) != 1 || recognizer.calls != 1 {
+		t.Errorf("%d dönüştürme, %d tanıma; beklenen 1, 1", env.conversions(t), recognizer.calls)
+	}
+	if !strings.HasSuffix(result.Metadata.ConvertedPath, "-api_lecture_notes.flac") {
+		t.Errorf("FLAC yolu iş ID'sini içermeli: %s", result.Metadata.ConvertedPath)
+	}
+
+	if len(result.OutputFiles) != 3 {
+		t.Fatalf("çıktılar = %v, beklenen json, srt, txt", result.OutputFiles)
+	}
+	for _, path := range result.OutputFiles {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("çıktı yazılmadı: %v", err)
+		}
+	}
+	srt, err := os.ReadFile(filepath.Join(env.cfg.OutputDir, "api_lecture_notes.srt"))
+	if err != nil || !strings.Contains(string(srt), "api lecture notes") {
+		t.Errorf("SRT = %q (%v)", srt, err)
+	}
+}
+
+// tanıma yarıda kalırsa resume dönüştürmeyi tekrarlamadan bitirmeli
+func TestResumeSkipsCompletedStages(t *testing.T) {
+	env := newFakeEnv(t, "")
+	recognizer := newCountingRecognizer(t, errors.New("bağlantı koptu"))
+	p := env.pipeline(t, recognizer)
+
+	if _, err := p.Run(context.Background(), env.audioFile); err == nil {
+		t.Fatal("ilk çalışmada hata bekleniyordu")
+	}
+
+	manifests, err := p.Jobs.List()
+	if err != nil || len(manifests) != 1 {
+		t.Fatalf("iş kayıtları = %v (%v)", manifests, err)
+	}
+	job := manifests[0]
+	if !job.Stage.Reached(jobs.StageConverted) || job.Stage.Reached(jobs.StageRecognized) {
+		t.Fatalf("yarıda kalan iş aşaması = %s", job.Stage)
+	}
+
+	result, err := p.Resume(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if result.Transcription.Transcript != "api lecture notes" || len(result.OutputFiles) != 3 {
+		t.Errorf("resume sonucu: %q, %v", result.Transcription.Transcript, result.OutputFiles)
+	}
+	if env.conversions(t) != 1 || recognizer.calls != 2 {
+		t.Errorf("%d dönüştürme, %d tanıma; beklenen 1, 2", env.conversions(t), recognizer.calls)
+	}
+
+	saved, err := p.Jobs.Load(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Stage != jobs.StageExported || saved.Error != "" {
+		t.Errorf("iş kaydı: aşama %s, hata %q", saved.Stage, saved.Error)
+	}
+}
+
+// aynı dosya ikinci kez işlendiğinde sonuç cache'ten gelmeli
+func TestRunUsesCache(t *testing.T) {
+	env := newFakeEnv(t, filepath.Join(t.TempDir(), "cache"))
+	recognizer := newCountingRecognizer(t, nil)
+
+	first, err := env.pipeline(t, recognizer).Run(context.Background(), env.audioFile)
+	if err != nil {
+		t.Fatalf("ilk Run: %v", err)
+	}
+	second, err := env.pipeline(t, recognizer).Run(context.Background(), env.audioFile)
+	if err != nil {
+		t.Fatalf("ikinci Run: %v", err)
+	}
+
+	if env.conversions(t) != 1 || recognizer.calls != 1 {
+		t.Errorf("%d dönüştürme, %d tanıma; cache'ten sonra beklenen 1, 1", env.conversions(t), recognizer.calls)
+	}
+	if second.Transcription.Transcript != first.Transcription.Transcript || len(second.OutputFiles) != 3 {
+		t.Errorf("cache sonucu: %q, %v", second.Transcription.Transcript, second.OutputFiles)
+	}
+}
@@ -0,0 +1,120 @@
+package speechclient
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/speech/apiv1/speechpb"
+
+	"spt2/pkg/models"
+)
+
+// fLaC + STREAMINFO (16 bit, bir saniye)
+func writeTestFLAC(t *testing.T, sampleRate int, channels int) string {
+	t.Helper()
+	streamInfo := make([]byte, 34)
+	streamInfo[10] = byte(sampleRate >> 12)
+	streamInfo[11] = byte(sampleRate >> 4)
+	streamInfo[12] = byte(sampleRate<<4) | byte((channels-1)<<1)
+	streamInfo[13] = byte(15 << 4)
+	binary.BigEndian.PutUint32(streamInfo[14:18], uint32(sampleRate))
+
+	path := filepath.Join(t.TempDir(), "ses.flac")
+	data := append([]byte("fLaC"), 0x80, 0, 0, 34)
+	if err := os.WriteFile(path, append(data, streamInfo...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// çok kanallı modda diarization istenmez; kanal konuşmacı kimliğidir
+func TestBuildRecognitionConfigSpeakers(t *testing.T) {
+	cfg := &models.AppConfig{LanguageCode: "tr-TR", TargetSampleRate: 16000, EnableDiarization: true, MinSpeakers: 2, MaxSpeakers: 4}
+
+	diarized := BuildRecognitionConfig(cfg)
+	if diarized.EnableSeparateRecognitionPerChannel || diarized.DiarizationConfig == nil ||
+		diarized.DiarizationConfig.MinSpeakerCount != 2 || diarized.DiarizationConfig.MaxSpeakerCount != 4 {
+		t.Errorf("diarization config = %+v", diarized.DiarizationConfig)
+	}
+
+	cfg.MultiChannel = true
+	perChannel := BuildRecognitionConfig(cfg)
+	if !perChannel.EnableSeparateRecognitionPerChannel || perChannel.DiarizationConfig != nil {
+		t.Errorf("çok kanallı config: kanal bazında %t, diarization %+v", perChannel.EnableSeparateRecognitionPerChannel, perChannel.DiarizationConfig)
+	}
+}
+
+func TestRecognitionConfigForChannels(t *testing.T) {
+	cfg := &models.AppConfig{LanguageCode: "tr-TR", TargetSampleRate: 16000, MultiChannel: true}
+
+	stereo := RecognitionConfigFor(&models.AudioMetadata{ConvertedChannels: 2}, cfg)
+	if stereo.AudioChannelCount != 2 || !stereo.EnableSeparateRecognitionPerChannel {
+		t.Errorf("stereo: %d kanal, kanal bazında %t", stereo.AudioChannelCount, stereo.EnableSeparateRecognitionPerChannel)
+	}
+
+	// multi_channel açık ama dosya mono: kanal bazında tanıma istenmez
+	mono := RecognitionConfigFor(&models.AudioMetadata{ConvertedChannels: 1}, cfg)
+	if mono.AudioChannelCount != 0 || mono.EnableSeparateRecognitionPerChannel {
+		t.Errorf("mono: %d kanal, kanal bazında %t", mono.AudioChannelCount, mono.EnableSeparateRecognitionPerChannel)
+	}
+}
+
+func TestVerifyFLAC(t *testing.T) {
+	stereo := writeTestFLAC(t, 16000, 2)
+	mono := writeTestFLAC(t, 16000, 1)
+
+	tests := []struct {
+		name    string
+		path    string
+		config  *speechpb.RecognitionConfig
+		wantErr bool
+	}{
+		{"mono uyumlu", mono, &speechpb.RecognitionConfig{SampleRateHertz: 16000}, false},
+		{"stereo uyumlu", stereo, &speechpb.RecognitionConfig{SampleRateHertz: 16000, AudioChannelCount: 2}, false},
+		{"stereo ama kanal sayısı yok", stereo, &speechpb.RecognitionConfig{SampleRateHertz: 16000}, true},
+		{"örnekleme hızı farklı", mono, &speechpb.RecognitionConfig{SampleRateHertz: 48000}, true},
+		{"dosya yok", filepath.Join(t.TempDir(), "yok.flac"), &speechpb.RecognitionConfig{SampleRateHertz: 16000}, true},
+	}
+	for _, test := range tests {
+		if err := VerifyFLAC(test.path, test.config); (err != nil) != test.wantErr {
+			t.Errorf("%s: hata %v", test.name, err)
+		}
+	}
+}
+
+// kanalların sonuçları tek zaman çizelgesinde, kanal konuşmacı olarak birleşmeli
+func TestConvertResultsPerChannel(t *testing.T) {
+	channelResult := func(channel int32, end float64, transcript string, words ...*speechpb.WordInfo) *speechpb.SpeechRecognitionResult {
+		return &speechpb.SpeechRecognitionResult{
+			ChannelTag:    channel,
+			ResultEndTime: seconds(end),
+			Alternatives:  []*speechpb.SpeechRecognitionAlternative{{Transcript: transcript, Confidence: 0.9, Words: words}},
+		}
+	}
+	results := []*speechpb.SpeechRecognitionResult{
+		channelResult(1, 2, "soru sorayım", pbWord("soru", 0, 0.5, 0), pbWord("sorayım", 0.5, 2, 0)),
+		channelResult(1, 6, "teşekkürler", pbWord("teşekkürler", 5, 6, 0)),
+		channelResult(2, 4, "buyurun", pbWord("buyurun", 2.5, 4, 0)),
+	}
+	result := convertResults(results, &speechpb.RecognitionConfig{LanguageCode: "tr-TR", EnableSeparateRecognitionPerChannel: true})
+
+	var order []string
+	for _, word := range result.Words {
+		order = append(order, word.Word)
+		if word.SpeakerTag != word.ChannelTag || word.ChannelTag == 0 {
+			t.Errorf("%s: konuşmacı %d, kanal %d", word.Word, word.SpeakerTag, word.ChannelTag)
+		}
+	}
+	if got := fmt.Sprint(order); got != "[soru sorayım buyurun teşekkürler]" {
+		t.Errorf("kelime sırası = %s", got)
+	}
+	if result.Transcript != "soru sorayım buyurun teşekkürler" {
+		t.Errorf("transcript = %q", result.Transcript)
+	}
+	if len(result.Segments) != 3 || result.Segments[1].ChannelTag != 2 {
+		t.Errorf("segmentler = %+v", result.Segments)
+	}
+}
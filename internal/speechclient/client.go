@@ -3,11 +3,13 @@ package speechclient
 import (
 	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	speech "cloud.google.com/go/speech/apiv1"
 	"cloud.google.com/go/speech/apiv1/speechpb"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"spt2/pkg/models"
 )
@@ -19,8 +21,20 @@ type SpeechClient struct {
 }
 
 //yeni speech client oluşturma
+//
+// cfg.SpeechEndpoint doluysa istemci Google yerine o adrese, TLS ve kimlik
+// doğrulama olmadan bağlanır (örn: cmd/fakespeech ile başlatılan sunucu).
 func NewSpeechClient(ctx context.Context, cfg *models.AppConfig) (*SpeechClient, error) {
-	client, err := speech.NewClient(ctx, option.WithCredentialsFile(cfg.GoogleCredentialsPath))
+	opts := []option.ClientOption{option.WithCredentialsFile(cfg.GoogleCredentialsPath)}
+	if cfg.SpeechEndpoint != "" {
+		opts = []option.ClientOption{
+			option.WithEndpoint(cfg.SpeechEndpoint),
+			option.WithoutAuthentication(),
+			option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		}
+	}
+
+	client, err := speech.NewClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("Speech client oluşturulamadı: %w", err)
 	}
@@ -36,6 +50,30 @@ func (sc *SpeechClient) Close() error {
 	return sc.client.Close()
 }
 
+// Recognize - Recognizer arayüzünün Google v1 implementasyonu
+func (sc *SpeechClient) Recognize(ctx context.Context, metadata *models.AudioMetadata, audioURI string, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	recognitionConfig := RecognitionConfigFor(metadata, cfg)
+	if err := VerifyFLAC(metadata.ConvertedPath, recognitionConfig); err != nil {
+		return nil, fmt.Errorf("ses dosyası tanıma ayarlarıyla uyumsuz: %w", err)
+	}
+
+	var result *models.TranscriptionResult
+	var err error
+	if audioURI == "" {
+		result, err = sc.SyncRecognize(ctx, metadata.ConvertedPath, recognitionConfig)
+	} else {
+		result, err = sc.LongRunningRecognize(ctx, audioURI, recognitionConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.AudioDuration = metadata.Duration
+	result.ProcessedAt = time.Now()
+
+	return result, nil
+}
+
 // LongRunningRecognize sends a long audio file to Google Speech API for transcription.
 func (sc *SpeechClient) LongRunningRecognize(ctx context.Context, gcsURI string, recognitionConfig *speechpb.RecognitionConfig) (*models.TranscriptionResult, error) {
 	req := &speechpb.LongRunningRecognizeRequest{
@@ -55,32 +93,5 @@ func (sc *SpeechClient) LongRunningRecognize(ctx context.Context, gcsURI string,
 		return nil, fmt.Errorf("sonuç beklenirken hata oluştu: %w", err)
 	}
 
-	var transcriptBuilder strings.Builder
-	var allWords []models.WordInfo
-
-	for _, result := range resp.Results {
-		alternative := result.Alternatives[0]
-		transcriptBuilder.WriteString(alternative.Transcript + " ")
-
-		for _, wordInfo := range alternative.Words {
-			startTime := float64(wordInfo.StartTime.Seconds) + float64(wordInfo.StartTime.Nanos)/1e9
-			endTime := float64(wordInfo.EndTime.Seconds) + float64(wordInfo.EndTime.Nanos)/1e9
-
-			allWords = append(allWords, models.WordInfo{
-				Word:       wordInfo.Word,
-				StartTime:  startTime,
-				EndTime:    endTime,
-				Confidence: float64(wordInfo.Confidence),
-				SpeakerTag: wordInfo.SpeakerTag,
-			})
-		}
-	}
-
-	fullTranscript := strings.TrimSpace(transcriptBuilder.String())
-
-	return &models.TranscriptionResult{
-		Transcript:   fullTranscript,
-		LanguageCode: recognitionConfig.LanguageCode,
-		Words:        allWords,
-	}, nil
-}
\ No newline at end of file
+	return convertResults(resp.Results, recognitionConfig), nil
+}
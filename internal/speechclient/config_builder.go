@@ -2,12 +2,16 @@ package speechclient
 
 import (
 	"cloud.google.com/go/speech/apiv1/speechpb"
+	"spt2/internal/language"
 	"spt2/pkg/models"
 )
 
 func BuildRecognitionConfig(cfg *models.AppConfig) *speechpb.RecognitionConfig {
+	languageCode, alternativeLanguageCodes := language.RecognitionLanguages(cfg)
+
 	recognitionConfig := &speechpb.RecognitionConfig{
-		LanguageCode: cfg.LanguageCode,
+		LanguageCode: languageCode,
+		AlternativeLanguageCodes: alternativeLanguageCodes,
 		Encoding:	  speechpb.RecognitionConfig_FLAC,
 		SampleRateHertz: int32(cfg.TargetSampleRate),
 		EnableAutomaticPunctuation: cfg.EnableAutomaticPunctuation,
@@ -15,6 +19,8 @@ func BuildRecognitionConfig(cfg *models.AppConfig) *speechpb.RecognitionConfig {
 		EnableWordConfidence:		cfg.EnableWordConfidence,
 		Model: 			cfg.Model,
 		UseEnhanced:	cfg.UseEnhanced,
+		MaxAlternatives: int32(cfg.MaxAlternatives),
+		ProfanityFilter: cfg.ProfanityFilter,
 
 	}
 
@@ -27,7 +33,10 @@ func BuildRecognitionConfig(cfg *models.AppConfig) *speechpb.RecognitionConfig {
 		}
 	}
 
-	if cfg.EnableDiarization {
+	// çok kanallı modda her kanal ayrı tanınır ve kanal konuşmacı kimliği olur
+	if cfg.MultiChannel {
+		recognitionConfig.EnableSeparateRecognitionPerChannel = true
+	} else if cfg.EnableDiarization {
 		recognitionConfig.DiarizationConfig = &speechpb.SpeakerDiarizationConfig{
 			EnableSpeakerDiarization: true,
 			MinSpeakerCount:		  int32(cfg.MinSpeakers),
@@ -0,0 +1,67 @@
+package speechclient
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+const fakeFixture = `{
+	"transcript": "proje planı hazır",
+	"words": [{"word": "proje", "start_time": 0, "end_time": 0.5, "speaker_tag": 1}],
+	"segments": [{"transcript": "proje planı hazır", "alternatives": [{"transcript": "proje planı hazır mı"}]}],
+	"speakers": [{"speaker_tag": 1, "text_of_speaker": "proje planı hazır"}],
+	"keyword_matches": [{"keyword": "proje", "matched_text": "proje"}]
+}`
+
+// bir çağrının sonucunu değiştirmek sonraki çağrıları etkilememeli
+func TestFakeRecognizerReturnsIndependentResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(path, []byte(fakeFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	recognizer, err := NewFakeRecognizer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := &models.AudioMetadata{FilePath: "ders.wav", Duration: 3}
+	cfg := &models.AppConfig{LanguageCode: "tr-TR"}
+	first, err := recognizer.Recognize(context.Background(), metadata, "", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Words[0].Word = "değişti"
+	first.Segments[0].Transcript = "değişti"
+	first.Segments[0].Alternatives[0].Transcript = "değişti"
+	first.Speakers[0].Transcript = "değişti"
+	first.KeywordMatches[0].Keyword = "değişti"
+
+	second, err := recognizer.Recognize(context.Background(), metadata, "", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Words[0].Word != "proje" ||
+		second.Segments[0].Transcript != "proje planı hazır" ||
+		second.Segments[0].Alternatives[0].Transcript != "proje planı hazır mı" ||
+		second.Speakers[0].Transcript != "proje planı hazır" ||
+		second.KeywordMatches[0].Keyword != "proje" {
+		t.Errorf("fixture önceki çağrıdan etkilendi: %+v", second)
+	}
+	if second.LanguageCode != "tr-TR" || second.AudioDuration != 3 {
+		t.Errorf("dil %q, süre %.0f", second.LanguageCode, second.AudioDuration)
+	}
+}
+
+func TestNewFakeRecognizerRejectsBrokenFixture(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(path, []byte("{yarım"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFakeRecognizer(path); err == nil {
+		t.Error("bozuk fixture için hata bekleniyordu")
+	}
+}
@@ -0,0 +1,60 @@
+package speechclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/speech/apiv1/speechpb"
+
+	"spt2/pkg/models"
+)
+
+// AsyncRecognizer - long-running işlemi başlatıp adını döndüren ve sonradan
+// bu adla işleme yeniden bağlanabilen tanıma motoru
+//
+// Pipeline, işlem adını job manifest'ine yazar; süreç Wait sırasında ölse bile
+// `spt2 resume` aynı işleme Resume ile bağlanır ve tekrar ücret ödenmez.
+type AsyncRecognizer interface {
+	Recognizer
+	Submit(ctx context.Context, metadata *models.AudioMetadata, audioURI string, cfg *models.AppConfig) (operationName string, err error)
+	Resume(ctx context.Context, operationName string, metadata *models.AudioMetadata, cfg *models.AppConfig) (*models.TranscriptionResult, error)
+}
+
+// Submit - LongRunningRecognize işlemini başlatır, beklemeden adını döndürür
+func (sc *SpeechClient) Submit(ctx context.Context, metadata *models.AudioMetadata, audioURI string, cfg *models.AppConfig) (string, error) {
+	recognitionConfig := RecognitionConfigFor(metadata, cfg)
+	if err := VerifyFLAC(metadata.ConvertedPath, recognitionConfig); err != nil {
+		return "", fmt.Errorf("ses dosyası tanıma ayarlarıyla uyumsuz: %w", err)
+	}
+
+	req := &speechpb.LongRunningRecognizeRequest{
+		Config: recognitionConfig,
+		Audio: &speechpb.RecognitionAudio{
+			AudioSource: &speechpb.RecognitionAudio_Uri{Uri: audioURI},
+		},
+	}
+
+	op, err := sc.client.LongRunningRecognize(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("uzun süreli tanıma başlatılamadı: %w", err)
+	}
+
+	return op.Name(), nil
+}
+
+// Resume - adı verilen long-running işleme yeniden bağlanır ve sonucunu bekler
+func (sc *SpeechClient) Resume(ctx context.Context, operationName string, metadata *models.AudioMetadata, cfg *models.AppConfig) (*models.TranscriptionResult, error) {
+	op := sc.client.LongRunningRecognizeOperation(operationName)
+
+	resp, err := op.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sonuç beklenirken hata oluştu (%s): %w", operationName, err)
+	}
+
+	result := convertResults(resp.Results, RecognitionConfigFor(metadata, cfg))
+	result.AudioDuration = metadata.Duration
+	result.ProcessedAt = time.Now()
+
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package speechclient
+
+import (
+	"context"
+	"fmt"
+
+	"spt2/pkg/models"
+)
+
+// Recognizer - ses dosyasını metne dönüştüren tanıma motorları için ortak arayüz
+//
+// audioURI, FLAC dosyasının yüklendiği adrestir (örn: gs://bucket/obj).
+// Boş ise motor metadata.ConvertedPath'teki dosyayı doğrudan (inline) okur;
+// bkz. UseSyncRecognition.
+// Google v1 implementasyonu SpeechClient'tır; FakeRecognizer ise kimlik
+// bilgisi gerektirmeden deterministik sonuç döndürür.
+type Recognizer interface {
+	Recognize(ctx context.Context, metadata *models.AudioMetadata, audioURI string, cfg *models.AppConfig) (*models.TranscriptionResult, error)
+	Close() error
+}
+
+// NewRecognizer - config'deki `backend` alanına göre tanıma motorunu oluşturur
+//
+// DESTEKLENEN BACKEND'LER:
+// - "google": Google Cloud Speech-to-Text v1 (varsayılan)
+// - "fake":   sabit/deterministik sonuç döndüren sahte motor (testler için)
+func NewRecognizer(ctx context.Context, cfg *models.AppConfig) (Recognizer, error) {
+	switch cfg.Backend {
+	case "", "google":
+		client, err := NewSpeechClient(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case "fake":
+		fake, err := NewFakeRecognizer(cfg.FakeResultFile)
+		if err != nil {
+			return nil, err
+		}
+		return fake, nil
+	default:
+		return nil, fmt.Errorf("bilinmeyen backend: %s", cfg.Backend)
+	}
+}
@@ -0,0 +1,168 @@
+package speechclient
+
+import (
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/speech/apiv1/speechpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"spt2/internal/analysis"
+	"spt2/internal/language"
+	"spt2/pkg/models"
+)
+
+// API sonuçlarını tek bir TranscriptionResult'a çevirme
+//
+// Recognize ve LongRunningRecognize aynı sonuç yapısını döndürdüğü için
+// iki yol da bu fonksiyonu kullanır; exporter'lar farkı görmez.
+// Her API sonucu zamanı, kanalı, dili ve alternatifleriyle bir Segment olur;
+// Transcript ve Words her sonucun ilk (en olası) alternatifinden birleştirilir.
+//
+// DIARIZATION: API, konuşmacı ayırma açıkken son sonuçta tüm sesin kelimelerini
+// konuşmacı etiketleriyle tekrar gönderir. Bu sonuç segment olarak eklenmez,
+// kelimeleri önceki (etiketsiz) kelimelerin yerine geçer.
+//
+// DİL: alternative_language_codes verildiyse API her sonucun dilini ayrı
+// tespit eder; Segment.LanguageCode config'teki yazıma çevrilir ve sonucun
+// LanguageCode'u en uzun süre konuşulan dil olur.
+//
+// ÇOK KANALLI: kanal bazında tanımada (EnableSeparateRecognitionPerChannel)
+// kanalların sonuçları ayrı ayrı gelir. Kelimelere ChannelTag konuşmacı olarak
+// yazılır; segmentler ve kelimeler başlangıç zamanına göre tek bir zaman
+// çizelgesinde birleştirilir.
+func convertResults(results []*speechpb.SpeechRecognitionResult, recognitionConfig *speechpb.RecognitionConfig) *models.TranscriptionResult {
+	perChannel := recognitionConfig.EnableSeparateRecognitionPerChannel
+	candidates := append([]string{recognitionConfig.LanguageCode}, recognitionConfig.AlternativeLanguageCodes...)
+
+	var allWords []models.WordInfo
+	var segments []models.Segment
+
+	// kanal bazında bir önceki sonucun bitişi (segment başlangıcı için)
+	previousEnd := make(map[int32]float64)
+
+	for i, result := range results {
+		if len(result.Alternatives) == 0 {
+			continue
+		}
+		alternative := result.Alternatives[0]
+
+		words := convertWords(alternative.Words)
+		if perChannel {
+			for j := range words {
+				words[j].ChannelTag = result.ChannelTag
+				words[j].SpeakerTag = result.ChannelTag
+			}
+		} else if i == len(results)-1 && analysis.IsDiarizationSummary(words, allWords) {
+			allWords = words
+			continue
+		}
+
+		allWords = append(allWords, words...)
+
+		segment := models.Segment{
+			Transcript:   strings.TrimSpace(alternative.Transcript),
+			Confidence:   float64(alternative.Confidence),
+			Start:        previousEnd[result.ChannelTag],
+			End:          durationSeconds(result.ResultEndTime),
+			ChannelTag:   result.ChannelTag,
+			LanguageCode: language.Normalize(result.LanguageCode, candidates),
+		}
+		if len(words) > 0 {
+			segment.Start = words[0].StartTime
+			if segment.End < words[len(words)-1].EndTime {
+				segment.End = words[len(words)-1].EndTime
+			}
+		}
+		previousEnd[result.ChannelTag] = segment.End
+
+		for _, other := range result.Alternatives[1:] {
+			segment.Alternatives = append(segment.Alternatives, models.Alternative{
+				Transcript: strings.TrimSpace(other.Transcript),
+				Confidence: float64(other.Confidence),
+			})
+		}
+		segments = append(segments, segment)
+	}
+
+	if perChannel {
+		sort.SliceStable(segments, func(a, b int) bool { return segments[a].Start < segments[b].Start })
+		sort.SliceStable(allWords, func(a, b int) bool { return allWords[a].StartTime < allWords[b].StartTime })
+	}
+
+	var transcriptBuilder strings.Builder
+	for _, segment := range segments {
+		if segment.Transcript != "" {
+			transcriptBuilder.WriteString(segment.Transcript + " ")
+		}
+	}
+	fullTranscript := strings.TrimSpace(transcriptBuilder.String())
+
+	languageCode := recognitionConfig.LanguageCode
+	if len(recognitionConfig.AlternativeLanguageCodes) > 0 {
+		if dominant := language.Dominant(segments); dominant != "" {
+			languageCode = dominant
+		}
+	}
+
+	return &models.TranscriptionResult{
+		Transcript:   fullTranscript,
+		Confidence:   weightedConfidence(segments),
+		LanguageCode: languageCode,
+		Words:        allWords,
+		Segments:     segments,
+	}
+}
+
+// OffsetResult - kelime ve segment zamanlarını offset saniye kaydırma
+// (örn. baştaki sessizliği kırpılmış FLAC'in sonucunu kaynak ses zamanına taşımak için)
+func OffsetResult(result *models.TranscriptionResult, offset float64) {
+	for i := range result.Words {
+		result.Words[i].StartTime += offset
+		result.Words[i].EndTime += offset
+	}
+	for i := range result.Segments {
+		result.Segments[i].Start += offset
+		result.Segments[i].End += offset
+	}
+}
+
+func convertWords(wordInfos []*speechpb.WordInfo) []models.WordInfo {
+	words := make([]models.WordInfo, 0, len(wordInfos))
+	for _, wordInfo := range wordInfos {
+		words = append(words, models.WordInfo{
+			Word:       wordInfo.Word,
+			StartTime:  durationSeconds(wordInfo.StartTime),
+			EndTime:    durationSeconds(wordInfo.EndTime),
+			Confidence: float64(wordInfo.Confidence),
+			SpeakerTag: wordInfo.SpeakerTag,
+		})
+	}
+	return words
+}
+
+// segment güvenlerinin süreye göre ağırlıklı ortalaması
+//
+// Süresi bilinmeyen segmentler (ResultEndTime ve kelime yoksa) eşit ağırlık alır.
+func weightedConfidence(segments []models.Segment) float64 {
+	var total, weights float64
+	for _, segment := range segments {
+		weight := segment.End - segment.Start
+		if weight <= 0 {
+			weight = 1
+		}
+		total += segment.Confidence * weight
+		weights += weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return total / weights
+}
+
+func durationSeconds(duration *durationpb.Duration) float64 {
+	if duration == nil {
+		return 0
+	}
+	return float64(duration.Seconds) + float64(duration.Nanos)/1e9
+}
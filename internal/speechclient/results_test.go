@@ -0,0 +1,154 @@
+package speechclient
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/speech/apiv1/speechpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"spt2/pkg/models"
+)
+
+func seconds(value float64) *durationpb.Duration {
+	return durationpb.New(time.Duration(value * float64(time.Second)))
+}
+
+func pbWord(word string, start float64, end float64, speaker int32) *speechpb.WordInfo {
+	return &speechpb.WordInfo{Word: word, StartTime: seconds(start), EndTime: seconds(end), SpeakerTag: speaker}
+}
+
+func pbResult(end float64, alternatives ...*speechpb.SpeechRecognitionAlternative) *speechpb.SpeechRecognitionResult {
+	return &speechpb.SpeechRecognitionResult{Alternatives: alternatives, ResultEndTime: seconds(end)}
+}
+
+func TestConvertResultsSegments(t *testing.T) {
+	results := []*speechpb.SpeechRecognitionResult{
+		pbResult(4,
+			&speechpb.SpeechRecognitionAlternative{Transcript: " Merhaba arkadaşlar. ", Confidence: 0.9,
+				Words: []*speechpb.WordInfo{pbWord("Merhaba", 1, 1.5, 0), pbWord("arkadaşlar.", 1.5, 2.5, 0)}},
+			&speechpb.SpeechRecognitionAlternative{Transcript: "Merhaba arkadaşlara", Confidence: 0.4},
+		),
+		pbResult(5), // alternatifsiz sonuç atlanır
+		pbResult(10,
+			&speechpb.SpeechRecognitionAlternative{Transcript: "Bugün API", Confidence: 0.6}, // kelime zamanı yok
+		),
+	}
+	result := convertResults(results, &speechpb.RecognitionConfig{LanguageCode: "tr-TR"})
+
+	if result.Transcript != "Merhaba arkadaşlar. Bugün API" {
+		t.Errorf("transcript = %q", result.Transcript)
+	}
+	if len(result.Words) != 2 || result.Words[1].StartTime != 1.5 {
+		t.Errorf("kelimeler = %+v", result.Words)
+	}
+	if len(result.Segments) != 2 {
+		t.Fatalf("%d segment, beklenen 2", len(result.Segments))
+	}
+
+	first, second := result.Segments[0], result.Segments[1]
+	// kelimesi olan segment ilk kelimeden, olmayan önceki segmentin sonundan başlar
+	if first.Start != 1 || first.End != 4 || second.Start != 4 || second.End != 10 {
+		t.Errorf("segment zamanları: %.1f-%.1f, %.1f-%.1f", first.Start, first.End, second.Start, second.End)
+	}
+	if len(first.Alternatives) != 1 || first.Alternatives[0].Transcript != "Merhaba arkadaşlara" {
+		t.Errorf("alternatifler = %+v", first.Alternatives)
+	}
+	if result.LanguageCode != "tr-TR" {
+		t.Errorf("dil = %s", result.LanguageCode)
+	}
+
+	// (0.9*3 + 0.6*6) / 9
+	if want := 0.7; math.Abs(result.Confidence-want) > 1e-6 {
+		t.Errorf("güven = %.4f, beklenen %.4f", result.Confidence, want)
+	}
+}
+
+// diarization özeti segment olmamalı, kelimeleri etiketsizlerin yerine geçmeli
+func TestConvertResultsDiarizationSummary(t *testing.T) {
+	results := []*speechpb.SpeechRecognitionResult{
+		pbResult(1, &speechpb.SpeechRecognitionAlternative{Transcript: "soru var",
+			Words: []*speechpb.WordInfo{pbWord("soru", 0, 0.5, 0), pbWord("var", 0.5, 1, 0)}}),
+		pbResult(2, &speechpb.SpeechRecognitionAlternative{Transcript: "evet",
+			Words: []*speechpb.WordInfo{pbWord("evet", 1.2, 2, 0)}}),
+		pbResult(2, &speechpb.SpeechRecognitionAlternative{
+			Words: []*speechpb.WordInfo{pbWord("soru", 0, 0.5, 1), pbWord("var", 0.5, 1, 1), pbWord("evet", 1.2, 2, 2)}}),
+	}
+	result := convertResults(results, &speechpb.RecognitionConfig{LanguageCode: "tr-TR"})
+
+	if len(result.Segments) != 2 || result.Transcript != "soru var evet" {
+		t.Errorf("%d segment, transcript %q", len(result.Segments), result.Transcript)
+	}
+	if len(result.Words) != 3 || result.Words[0].SpeakerTag != 1 || result.Words[2].SpeakerTag != 2 {
+		t.Errorf("kelimeler = %+v", result.Words)
+	}
+}
+
+func TestWeightedConfidence(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments [][3]float64 // start, end, confidence
+		want     float64
+	}{
+		{"boş", nil, 0},
+		{"tek", [][3]float64{{0, 5, 0.8}}, 0.8},
+		{"uzun segment ağır basar", [][3]float64{{0, 9, 0.9}, {9, 10, 0.1}}, 0.82},
+		{"süresiz segment eşit ağırlık", [][3]float64{{0, 0, 0.5}, {3, 3, 0.7}}, 0.6},
+	}
+	for _, test := range tests {
+		var segments []models.Segment
+		for _, s := range test.segments {
+			segments = append(segments, models.Segment{Start: s[0], End: s[1], Confidence: s[2]})
+		}
+		if got := weightedConfidence(segments); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("%s: %.4f, beklenen %.4f", test.name, got, test.want)
+		}
+	}
+}
+
+func TestOffsetResult(t *testing.T) {
+	result := &models.TranscriptionResult{
+		Words:    []models.WordInfo{{StartTime: 1, EndTime: 2}},
+		Segments: []models.Segment{{Start: 0, End: 2}},
+	}
+	OffsetResult(result, 30)
+	if result.Words[0].StartTime != 31 || result.Words[0].EndTime != 32 || result.Segments[0].Start != 30 || result.Segments[0].End != 32 {
+		t.Errorf("kaydırılmış sonuç = %+v", result)
+	}
+}
+
+// API'ın küçük harfli dil kodları config yazımına çevrilmeli; sonucun dili
+// en uzun konuşulan dil olmalı
+func TestConvertResultsLanguages(t *testing.T) {
+	languageResult := func(code string, end float64, transcript string) *speechpb.SpeechRecognitionResult {
+		result := pbResult(end, &speechpb.SpeechRecognitionAlternative{Transcript: transcript})
+		result.LanguageCode = code
+		return result
+	}
+	results := []*speechpb.SpeechRecognitionResult{
+		languageResult("en-us", 5, "Welcome to the course"),
+		languageResult("tr-tr", 20, "Bugün REST API konusunu işleyeceğiz"),
+		languageResult("en-us", 24, "Any questions"),
+	}
+	recognitionConfig := &speechpb.RecognitionConfig{LanguageCode: "en-US", AlternativeLanguageCodes: []string{"tr-TR"}}
+	result := convertResults(results, recognitionConfig)
+
+	var codes []string
+	for _, segment := range result.Segments {
+		codes = append(codes, segment.LanguageCode)
+	}
+	if fmt.Sprint(codes) != "[en-US tr-TR en-US]" {
+		t.Errorf("segment dilleri = %v", codes)
+	}
+	if result.LanguageCode != "tr-TR" {
+		t.Errorf("sonucun dili %s, beklenen tr-TR (15 sn'ye karşı 9 sn)", result.LanguageCode)
+	}
+
+	// alternatif dil yoksa sonucun dili istekteki dil
+	recognitionConfig.AlternativeLanguageCodes = nil
+	if result := convertResults(results, recognitionConfig); result.LanguageCode != "en-US" {
+		t.Errorf("tek dilde sonucun dili %s", result.LanguageCode)
+	}
+}
@@ -0,0 +1,271 @@
+package speechclient
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"spt2/internal/audio"
+	"spt2/internal/language"
+	"spt2/pkg/models"
+)
+
+// örtüşmede iki parçanın aynı kelimesi sayılmak için zaman farkı sınırı (saniye)
+const overlapMatchTolerance = 0.5
+
+// StitchResults - parçaların deşifre sonuçlarını tek bir TranscriptionResult'a birleştirme
+//
+// results[i], chunks[i]'nin sonucudur ve zamanları parçanın başına görelidir;
+// kelime ve segment zamanları chunk.Start kadar kaydırılır.
+//
+// ÖRTÜŞME: her kelime, ortası hangi parçanın KeepStart-KeepEnd aralığına
+// düşüyorsa o parçadan alınır. Örtüşmede iki parçada da tanınan kelimeler
+// (aynı yazım, yakın zaman) eşleştirilir; iki parçanın zamanları biraz kaysa
+// bile eşlerden yalnızca biri kalır. Kelimeleri kısmen atılan segmentlerin
+// metni kalan kelimelerden yeniden kurulur (alternatifleri atılır).
+//
+// KONUŞMACILAR: diarization etiketleri parçadan parçaya farklı olabilir.
+// Örtüşmedeki eşlerin etiketleri oylanarak sonraki parçanın etiketleri
+// öncekine eşlenir; kanıt yoksa etiket olduğu gibi kalır. Kanal bazında
+// tanımada etiket kanaldır, eşleme yapılmaz.
+func StitchResults(metadata *models.AudioMetadata, chunks []audio.Chunk, results []*models.TranscriptionResult) *models.TranscriptionResult {
+	words := make([][]models.WordInfo, len(chunks))
+	keep := make([][]bool, len(chunks))
+
+	for i, chunk := range chunks {
+		words[i] = shiftedWords(results[i].Words, chunk.Start)
+		keep[i] = make([]bool, len(words[i]))
+		for j, word := range words[i] {
+			middle := wordMiddle(word)
+			keep[i][j] = middle >= chunk.KeepStart && (middle < chunk.KeepEnd || i == len(chunks)-1)
+		}
+
+		if i == 0 {
+			continue
+		}
+		pairs := matchOverlap(words[i-1], words[i], chunk.Start, chunks[i-1].End)
+		for _, pair := range pairs {
+			middle := (wordMiddle(words[i-1][pair[0]]) + wordMiddle(words[i][pair[1]])) / 2
+			fromPrevious := middle < chunk.KeepStart
+			keep[i-1][pair[0]] = fromPrevious
+			keep[i][pair[1]] = !fromPrevious
+		}
+		mapSpeakers(words[i-1], words[i], pairs)
+	}
+
+	var allWords []models.WordInfo
+	var segments []models.Segment
+	for i, chunk := range chunks {
+		for j, word := range words[i] {
+			if keep[i][j] {
+				allWords = append(allWords, word)
+			}
+		}
+		for _, segment := range results[i].Segments {
+			if stitched, ok := stitchSegment(segment, chunk, words[i], keep[i], i == len(chunks)-1); ok {
+				segments = append(segments, stitched)
+			}
+		}
+	}
+	sort.SliceStable(segments, func(a, b int) bool { return segments[a].Start < segments[b].Start })
+	sort.SliceStable(allWords, func(a, b int) bool { return allWords[a].StartTime < allWords[b].StartTime })
+
+	var transcriptBuilder strings.Builder
+	for _, segment := range segments {
+		if segment.Transcript != "" {
+			transcriptBuilder.WriteString(segment.Transcript + " ")
+		}
+	}
+
+	languageCode := ""
+	if len(results) > 0 {
+		languageCode = results[0].LanguageCode
+	}
+	if dominant := language.Dominant(segments); dominant != "" {
+		languageCode = dominant
+	}
+
+	return &models.TranscriptionResult{
+		Transcript:    strings.TrimSpace(transcriptBuilder.String()),
+		Confidence:    weightedConfidence(segments),
+		LanguageCode:  languageCode,
+		Words:         allWords,
+		Segments:      segments,
+		AudioDuration: metadata.Duration,
+		ProcessedAt:   time.Now(),
+	}
+}
+
+func shiftedWords(words []models.WordInfo, offset float64) []models.WordInfo {
+	shifted := make([]models.WordInfo, len(words))
+	for i, word := range words {
+		word.StartTime += offset
+		word.EndTime += offset
+		shifted[i] = word
+	}
+	return shifted
+}
+
+func wordMiddle(word models.WordInfo) float64 {
+	return (word.StartTime + word.EndTime) / 2
+}
+
+// örtüşme aralığında ([overlapStart, overlapEnd]) iki parçanın aynı kelimeleri
+//
+// Kelimeler sırayla ilerlenerek eşleştirilir; dönen her çift
+// (önceki parçadaki sıra, sonraki parçadaki sıra) şeklindedir.
+func matchOverlap(previous []models.WordInfo, next []models.WordInfo, overlapStart float64, overlapEnd float64) [][2]int {
+	var pairs [][2]int
+	nextIndex := 0
+	for i, word := range previous {
+		if word.EndTime < overlapStart || word.StartTime > overlapEnd {
+			continue
+		}
+		for j := nextIndex; j < len(next) && next[j].StartTime <= overlapEnd; j++ {
+			candidate := next[j]
+			if candidate.ChannelTag != word.ChannelTag {
+				continue
+			}
+			if math.Abs(wordMiddle(candidate)-wordMiddle(word)) <= overlapMatchTolerance && sameWord(candidate.Word, word.Word) {
+				pairs = append(pairs, [2]int{i, j})
+				nextIndex = j + 1
+				break
+			}
+		}
+	}
+	return pairs
+}
+
+// noktalama ve büyük/küçük harf farkı olmadan aynı kelime mi
+func sameWord(a string, b string) bool {
+	trim := func(word string) string {
+		return strings.TrimFunc(word, unicode.IsPunct)
+	}
+	return strings.EqualFold(trim(a), trim(b))
+}
+
+// sonraki parçanın konuşmacı etiketlerini örtüşmedeki eşlerin oylarıyla
+// önceki parçanın etiketlerine çevirme
+func mapSpeakers(previous []models.WordInfo, next []models.WordInfo, pairs [][2]int) {
+	votes := make(map[int32]map[int32]int)
+	for _, pair := range pairs {
+		from, to := next[pair[1]].SpeakerTag, previous[pair[0]].SpeakerTag
+		if from == 0 || to == 0 || next[pair[1]].ChannelTag != 0 {
+			continue
+		}
+		if votes[from] == nil {
+			votes[from] = make(map[int32]int)
+		}
+		votes[from][to]++
+	}
+	if len(votes) == 0 {
+		return
+	}
+
+	// en çok oyu alan eşlemeden başlayarak her hedef etikete tek kaynak
+	type candidate struct {
+		from, to int32
+		count    int
+	}
+	var candidates []candidate
+	for from, targets := range votes {
+		for to, count := range targets {
+			candidates = append(candidates, candidate{from, to, count})
+		}
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].count != candidates[b].count {
+			return candidates[a].count > candidates[b].count
+		}
+		if candidates[a].from != candidates[b].from {
+			return candidates[a].from < candidates[b].from
+		}
+		return candidates[a].to < candidates[b].to
+	})
+
+	mapping := make(map[int32]int32)
+	taken := make(map[int32]bool)
+	for _, c := range candidates {
+		if _, ok := mapping[c.from]; ok || taken[c.to] {
+			continue
+		}
+		mapping[c.from] = c.to
+		taken[c.to] = true
+	}
+
+	// eşlenmeyen etiketler, eşlenen bir hedefle çakışırsa boştaki ilk etikete taşınır
+	used := make(map[int32]bool)
+	for to := range taken {
+		used[to] = true
+	}
+	for _, word := range next {
+		tag := word.SpeakerTag
+		if _, ok := mapping[tag]; ok || tag == 0 {
+			continue
+		}
+		if !used[tag] {
+			mapping[tag] = tag
+			used[tag] = true
+			continue
+		}
+		free := int32(1)
+		for used[free] {
+			free++
+		}
+		mapping[tag] = free
+		used[free] = true
+	}
+
+	for i := range next {
+		if tag, ok := mapping[next[i].SpeakerTag]; ok && next[i].ChannelTag == 0 {
+			next[i].SpeakerTag = tag
+		}
+	}
+}
+
+// segmenti kaynak zamanına kaydırma ve örtüşmede atılan kelimelere göre kırpma
+//
+// Segmentin kelimeleri zamanına (ve kanalına) göre bulunur. Hepsi kaldıysa
+// segment olduğu gibi, hiçbiri kalmadıysa hiç alınmaz; kelimesi olmayan
+// segmentlerde ortasının Keep aralığına düşmesine bakılır.
+func stitchSegment(segment models.Segment, chunk audio.Chunk, words []models.WordInfo, keep []bool, last bool) (models.Segment, bool) {
+	segment.Start += chunk.Start
+	segment.End += chunk.Start
+
+	var kept []models.WordInfo
+	total := 0
+	for i, word := range words {
+		middle := wordMiddle(word)
+		if middle < segment.Start || middle > segment.End {
+			continue
+		}
+		if segment.ChannelTag != 0 && word.ChannelTag != segment.ChannelTag {
+			continue
+		}
+		total++
+		if keep[i] {
+			kept = append(kept, word)
+		}
+	}
+
+	if total == 0 {
+		middle := (segment.Start + segment.End) / 2
+		return segment, middle >= chunk.KeepStart && (middle < chunk.KeepEnd || last)
+	}
+	if len(kept) == 0 {
+		return segment, false
+	}
+	if len(kept) < total {
+		texts := make([]string, len(kept))
+		for i, word := range kept {
+			texts[i] = word.Word
+		}
+		segment.Transcript = strings.Join(texts, " ")
+		segment.Start = kept[0].StartTime
+		segment.End = kept[len(kept)-1].EndTime
+		segment.Alternatives = nil
+	}
+	return segment, true
+}
@@ -0,0 +1,101 @@
+package speechclient
+
+import (
+	"math"
+	"testing"
+
+	"spt2/internal/audio"
+	"spt2/pkg/models"
+)
+
+// iki parça: 0-11 ve 9-20 sn, bölme noktası 10. sn
+var testChunks = []audio.Chunk{
+	{Index: 0, Start: 0, End: 11, KeepStart: 0, KeepEnd: 10},
+	{Index: 1, Start: 9, End: 20, KeepStart: 10, KeepEnd: 20},
+}
+
+func testWord(word string, start float64, end float64, speaker int32) models.WordInfo {
+	return models.WordInfo{Word: word, StartTime: start, EndTime: end, Confidence: 0.9, SpeakerTag: speaker}
+}
+
+func testStitchResults() []*models.TranscriptionResult {
+	// zamanlar parçanın başına görelidir
+	first := &models.TranscriptionResult{
+		LanguageCode: "tr-TR",
+		Words: []models.WordInfo{
+			testWord("bir", 0, 1, 1),
+			testWord("iki", 9.2, 9.6, 1),
+			testWord("üç", 9.8, 10.1, 2), // ortası 9.95: tek başına ilk parçada kalırdı
+		},
+		Segments: []models.Segment{{Transcript: "bir iki üç", Confidence: 0.9, Start: 0, End: 10.1}},
+	}
+	second := &models.TranscriptionResult{
+		LanguageCode: "tr-TR",
+		Words: []models.WordInfo{
+			testWord("iki", 0.25, 0.65, 2), // 9.25-9.65
+			testWord("üç.", 1.0, 1.3, 1),   // 10.0-10.3, ortası 10.15: tek başına ikinci parçada kalırdı
+			testWord("dört", 5, 5.5, 1),    // 14-14.5
+			testWord("beş", 6, 6.5, 2),     // 15-15.5
+		},
+		Segments: []models.Segment{{Transcript: "iki üç. dört beş", Confidence: 0.9, Start: 0.25, End: 6.5}},
+	}
+	return []*models.TranscriptionResult{first, second}
+}
+
+func TestStitchResultsDeduplicatesOverlap(t *testing.T) {
+	result := StitchResults(&models.AudioMetadata{Duration: 20}, testChunks, testStitchResults())
+
+	want := []struct {
+		word  string
+		start float64
+	}{{"bir", 0}, {"iki", 9.2}, {"üç.", 10.0}, {"dört", 14}, {"beş", 15}}
+	if len(result.Words) != len(want) {
+		t.Fatalf("kelimeler = %v, beklenen %d kelime", result.Words, len(want))
+	}
+	for i, w := range want {
+		if result.Words[i].Word != w.word || math.Abs(result.Words[i].StartTime-w.start) > 1e-9 {
+			t.Errorf("%d. kelime = %q @%.2f, beklenen %q @%.2f", i, result.Words[i].Word, result.Words[i].StartTime, w.word, w.start)
+		}
+	}
+
+	if result.Transcript != "bir iki üç. dört beş" {
+		t.Errorf("transcript = %q", result.Transcript)
+	}
+	if len(result.Segments) != 2 {
+		t.Fatalf("segment sayısı = %d, beklenen 2: %v", len(result.Segments), result.Segments)
+	}
+	if segment := result.Segments[1]; segment.Start != 10.0 || segment.End != 15.5 {
+		t.Errorf("ikinci segment %.2f-%.2f, beklenen 10.00-15.50 (kaydırılmış ve kırpılmış)", segment.Start, segment.End)
+	}
+	if result.AudioDuration != 20 || result.LanguageCode != "tr-TR" {
+		t.Errorf("süre/dil = %.1f/%s", result.AudioDuration, result.LanguageCode)
+	}
+}
+
+// ikinci parçada etiketler yer değiştirmiş: örtüşmedeki eşlerle geri çevrilmeli
+func TestStitchResultsMapsSpeakers(t *testing.T) {
+	result := StitchResults(&models.AudioMetadata{Duration: 20}, testChunks, testStitchResults())
+
+	want := map[string]int32{"bir": 1, "iki": 1, "üç.": 2, "dört": 2, "beş": 1}
+	for _, word := range result.Words {
+		if word.SpeakerTag != want[word.Word] {
+			t.Errorf("%q konuşmacısı %d, beklenen %d", word.Word, word.SpeakerTag, want[word.Word])
+		}
+	}
+}
+
+func TestStitchResultsSingleChunk(t *testing.T) {
+	chunks := []audio.Chunk{{Start: 0, End: 5, KeepStart: 0, KeepEnd: 5}}
+	results := []*models.TranscriptionResult{{
+		Words:    []models.WordInfo{testWord("tek", 4.6, 5, 0)},
+		Segments: []models.Segment{{Transcript: "tek", Confidence: 0.8, Start: 4.6, End: 5}},
+	}}
+
+	result := StitchResults(&models.AudioMetadata{Duration: 5}, chunks, results)
+	if len(result.Words) != 1 || len(result.Segments) != 1 {
+		t.Fatalf("son parçanın sonundaki kelime atıldı: %v", result.Words)
+	}
+	if result.Confidence != 0.8 {
+		t.Errorf("güven = %.2f, beklenen 0.80", result.Confidence)
+	}
+}
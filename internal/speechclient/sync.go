@@ -0,0 +1,68 @@
+package speechclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"cloud.google.com/go/speech/apiv1/speechpb"
+
+	"spt2/internal/audio"
+	"spt2/pkg/models"
+)
+
+// senkron Recognize limitleri: inline içerik en fazla 10MB, ses en fazla 60 saniye
+const (
+	maxInlineAudioBytes = 10 * 1024 * 1024
+	maxSyncDuration     = 60.0
+)
+
+// UseSyncRecognition - dosyanın senkron (inline) Recognize ile gönderilip
+// gönderilmeyeceğine karar verir
+//
+// KURALLAR (config: sync_recognition):
+// - "never":  her zaman GCS + LongRunningRecognize
+// - "always": FLAC 10MB'ın ve 60 saniyenin altındaysa senkron
+// - "auto":   ayrıca FLAC sync_max_duration'dan kısa olmalı
+//
+// Süre bilinmiyorsa (0) her modda long-running kullanılır; API 60 saniyeden
+// uzun sesi senkron istekte reddeder. Senkron yolda GCS'ye yükleme atlanır ve Recognizer'a boş URI verilir.
+func UseSyncRecognition(metadata *models.AudioMetadata, cfg *models.AppConfig) bool {
+	if cfg.SyncRecognition == "never" || metadata.ConvertedPath == "" {
+		return false
+	}
+
+	fileInfo, err := os.Stat(metadata.ConvertedPath)
+	if err != nil || fileInfo.Size() > maxInlineAudioBytes {
+		return false
+	}
+
+	duration := audio.ConvertedDuration(metadata)
+	if duration <= 0 || duration > maxSyncDuration {
+		return false
+	}
+
+	return cfg.SyncRecognition == "always" || duration <= cfg.SyncMaxDuration
+}
+
+// SyncRecognize - kısa bir FLAC dosyasını içeriğiyle birlikte senkron tanır
+func (sc *SpeechClient) SyncRecognize(ctx context.Context, flacPath string, recognitionConfig *speechpb.RecognitionConfig) (*models.TranscriptionResult, error) {
+	content, err := os.ReadFile(flacPath)
+	if err != nil {
+		return nil, fmt.Errorf("FLAC dosyası okunamadı: %w", err)
+	}
+
+	req := &speechpb.RecognizeRequest{
+		Config: recognitionConfig,
+		Audio: &speechpb.RecognitionAudio{
+			AudioSource: &speechpb.RecognitionAudio_Content{Content: content},
+		},
+	}
+
+	resp, err := sc.client.Recognize(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("senkron tanıma başarısız: %w", err)
+	}
+
+	return convertResults(resp.Results, recognitionConfig), nil
+}
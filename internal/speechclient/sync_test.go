@@ -0,0 +1,59 @@
+package speechclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"spt2/pkg/models"
+)
+
+func TestUseSyncRecognition(t *testing.T) {
+	dir := t.TempDir()
+	small := filepath.Join(dir, "kisa.flac")
+	if err := os.WriteFile(small, make([]byte, 1024), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// inline limitinden bir bayt büyük (seyrek dosya)
+	large := filepath.Join(dir, "buyuk.flac")
+	file, err := os.Create(large)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Truncate(maxInlineAudioBytes + 1); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	tests := []struct {
+		name     string
+		mode     string
+		path     string
+		duration float64
+		want     bool
+	}{
+		{"never", "never", small, 5, false},
+		{"auto kısa", "auto", small, 10, true},
+		{"auto sync_max_duration sınırında", "auto", small, 15, true},
+		{"auto sync_max_duration üstü", "auto", small, 30, false},
+		{"always 60 sn'ye kadar", "always", small, 60, true},
+		{"always 60 sn üstü", "always", small, 61, false},
+		{"always 10MB üstü", "always", large, 5, false},
+		{"süre bilinmiyor", "always", small, 0, false},
+		{"FLAC yok", "always", "", 5, false},
+		{"FLAC okunamıyor", "always", filepath.Join(dir, "yok.flac"), 5, false},
+	}
+	for _, test := range tests {
+		cfg := &models.AppConfig{SyncRecognition: test.mode, SyncMaxDuration: 15}
+		metadata := &models.AudioMetadata{ConvertedPath: test.path, ConvertedDuration: test.duration}
+		if got := UseSyncRecognition(metadata, cfg); got != test.want {
+			t.Errorf("%s: UseSyncRecognition = %t, beklenen %t", test.name, got, test.want)
+		}
+	}
+
+	// FLAC süresi yoksa kaynak sesin süresi kullanılır
+	cfg := &models.AppConfig{SyncRecognition: "auto", SyncMaxDuration: 15}
+	if !UseSyncRecognition(&models.AudioMetadata{ConvertedPath: small, Duration: 10}, cfg) {
+		t.Error("kaynak süresi 10 sn iken senkron bekleniyordu")
+	}
+}
@@ -0,0 +1,51 @@
+package speechclient
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/speech/apiv1/speechpb"
+
+	"spt2/internal/audio"
+	"spt2/pkg/models"
+)
+
+// RecognitionConfigFor - config'den oluşturulan RecognitionConfig'i
+// dönüştürülmüş FLAC'in kanal sayısıyla tamamlar
+//
+// API, çok kanallı FLAC için AudioChannelCount'un header ile aynı olmasını
+// bekler; mono dosyada alan boş bırakılır. multi_channel açık ama dosya
+// monoysa kanal bazında tanıma istenmez.
+func RecognitionConfigFor(metadata *models.AudioMetadata, cfg *models.AppConfig) *speechpb.RecognitionConfig {
+	recognitionConfig := BuildRecognitionConfig(cfg)
+	if metadata.ConvertedChannels > 1 {
+		recognitionConfig.AudioChannelCount = int32(metadata.ConvertedChannels)
+	} else {
+		recognitionConfig.EnableSeparateRecognitionPerChannel = false
+	}
+	return recognitionConfig
+}
+
+// VerifyFLAC - FLAC header'ının gönderilecek RecognitionConfig ile uyumunu kontrol eder
+//
+// Uyuşmazlıkta API ya hata döner ya da sessizce yanlış sonuç üretir; istek
+// göndermeden önce yakalamak ücretli bir denemeyi önler.
+func VerifyFLAC(flacPath string, recognitionConfig *speechpb.RecognitionConfig) error {
+	info, err := audio.ReadFLACInfo(flacPath)
+	if err != nil {
+		return err
+	}
+
+	if int32(info.SampleRate) != recognitionConfig.SampleRateHertz {
+		return fmt.Errorf("FLAC örnekleme hızı (%d Hz) RecognitionConfig ile uyuşmuyor (%d Hz)", info.SampleRate, recognitionConfig.SampleRateHertz)
+	}
+
+	channels := recognitionConfig.AudioChannelCount
+	if channels == 0 {
+		channels = 1
+	}
+	if int32(info.Channels) != channels {
+		return fmt.Errorf("FLAC kanal sayısı (%d) RecognitionConfig ile uyuşmuyor (%d)", info.Channels, channels)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// GCSStore - Google Cloud Storage üzerinde ObjectStore implementasyonu
+//
+// storage.Client bir kez oluşturulur ve tüm işlemlerde tekrar kullanılır.
+type GCSStore struct {
+	client     *storage.Client
+	bucketName string
+}
+
+// yeni GCS store oluşturma
+func NewGCSStore(ctx context.Context, bucketName, credentialsFile string) (*GCSStore, error) {
+	client, err := storage.NewClient(ctx, option.WithCredentialsFile(credentialsFile))
+	if err != nil {
+		return nil, fmt.Errorf("storage client oluşturulamadı: %w", err)
+	}
+
+	return &GCSStore{
+		client:     client,
+		bucketName: bucketName,
+	}, nil
+}
+
+// Put uploads a file to the GCS bucket and returns the GCS URI.
+func (gs *GCSStore) Put(ctx context.Context, localFilePath, objectName string, metadata map[string]string) (string, error) {
+	file, err := os.Open(localFilePath)
+	if err != nil {
+		return "", fmt.Errorf("yerel dosya açılamadı: %w", err)
+	}
+	defer file.Close()
+
+	object := gs.client.Bucket(gs.bucketName).Object(objectName)
+
+	// Upload the file
+	wc := object.NewWriter(ctx)
+	wc.ContentType = "audio/flac"
+	wc.Metadata = metadata
+	if _, err = io.Copy(wc, file); err != nil {
+		wc.Close()
+		return "", fmt.Errorf("dosya GCS'ye kopyalanamadı: %w", err)
+	}
+	if err := wc.Close(); err != nil {
+		return "", fmt.Errorf("GCS writer kapatılamadı: %w", err)
+	}
+
+	return gs.URI(objectName), nil
+}
+
+// objeyi bucket'tan silme
+func (gs *GCSStore) Delete(ctx context.Context, objectName string) error {
+	if err := gs.client.Bucket(gs.bucketName).Object(objectName).Delete(ctx); err != nil {
+		return fmt.Errorf("GCS objesi silinemedi '%s': %w", objectName, err)
+	}
+	return nil
+}
+
+// bucket'taki tüm objeleri listeleme
+func (gs *GCSStore) List(ctx context.Context) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+
+	it := gs.client.Bucket(gs.bucketName).Objects(ctx, &storage.Query{})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("GCS objeleri listelenemedi: %w", err)
+		}
+
+		objects = append(objects, ObjectInfo{
+			Name:     attrs.Name,
+			URI:      gs.URI(attrs.Name),
+			Size:     attrs.Size,
+			Created:  attrs.Created,
+			Metadata: attrs.Metadata,
+		})
+	}
+
+	return objects, nil
+}
+
+func (gs *GCSStore) URI(objectName string) string {
+	return fmt.Sprintf("gs://%s/%s", gs.bucketName, objectName)
+}
+
+// V4 imzalı GET adresi (credentials dosyasındaki servis hesabı ile imzalanır)
+func (gs *GCSStore) SignedURL(objectName string, expiry time.Duration) (string, error) {
+	url, err := gs.client.Bucket(gs.bucketName).SignedURL(objectName, &storage.SignedURLOptions{
+		Method:  "GET",
+		Expires: time.Now().Add(expiry),
+		Scheme:  storage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", fmt.Errorf("imzalı URL oluşturulamadı: %w", err)
+	}
+	return url, nil
+}
+
+func (gs *GCSStore) Close() error {
+	return gs.client.Close()
+}
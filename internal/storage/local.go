@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStore - objeleri yerel bir dizine kopyalayan ObjectStore
+//
+// Bulut hesabı olmadan yükleme adımını çalıştırmak için kullanılır.
+// Obje metadata'sı yanındaki "<obje>.meta.json" dosyasında tutulur.
+// Google backend file:// adreslerini okuyamaz; fake backend ile birlikte
+// kullanılmalıdır.
+type LocalStore struct {
+	dir string
+}
+
+// yeni local store oluşturma (dizin yoksa oluşturulur)
+func NewLocalStore(dir string) (*LocalStore, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("storage dizini çözümlenemedi: %w", err)
+	}
+	if err := os.MkdirAll(absDir, 0755); err != nil {
+		return nil, fmt.Errorf("storage dizini oluşturulamadı: %w", err)
+	}
+
+	return &LocalStore{dir: absDir}, nil
+}
+
+// metadata dosyalarının uzantısı
+const localMetaSuffix = ".meta.json"
+
+func (ls *LocalStore) Put(ctx context.Context, localFilePath, objectName string, metadata map[string]string) (string, error) {
+	src, err := os.Open(localFilePath)
+	if err != nil {
+		return "", fmt.Errorf("yerel dosya açılamadı: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(ls.path(objectName))
+	if err != nil {
+		return "", fmt.Errorf("hedef dosya oluşturulamadı: %w", err)
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return "", fmt.Errorf("dosya kopyalanamadı: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return "", fmt.Errorf("hedef dosya kapatılamadı: %w", err)
+	}
+
+	if len(metadata) > 0 {
+		metaData, err := json.Marshal(metadata)
+		if err != nil {
+			return "", fmt.Errorf("obje metadata'sı oluşturulamadı: %w", err)
+		}
+		if err := os.WriteFile(ls.path(objectName)+localMetaSuffix, metaData, 0644); err != nil {
+			return "", fmt.Errorf("obje metadata'sı yazılamadı: %w", err)
+		}
+	}
+
+	return ls.URI(objectName), nil
+}
+
+func (ls *LocalStore) Delete(ctx context.Context, objectName string) error {
+	if err := os.Remove(ls.path(objectName)); err != nil {
+		return fmt.Errorf("obje silinemedi '%s': %w", objectName, err)
+	}
+
+	// metadata dosyası yoksa sorun değil
+	if err := os.Remove(ls.path(objectName) + localMetaSuffix); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("obje metadata'sı silinemedi '%s': %w", objectName, err)
+	}
+	return nil
+}
+
+// dizindeki objeleri (metadata dosyaları hariç) listeleme
+func (ls *LocalStore) List(ctx context.Context) ([]ObjectInfo, error) {
+	entries, err := os.ReadDir(ls.dir)
+	if err != nil {
+		return nil, fmt.Errorf("storage dizini okunamadı: %w", err)
+	}
+
+	var objects []ObjectInfo
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), localMetaSuffix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("obje bilgisi okunamadı '%s': %w", entry.Name(), err)
+		}
+
+		object := ObjectInfo{
+			Name:    entry.Name(),
+			URI:     ls.URI(entry.Name()),
+			Size:    info.Size(),
+			Created: info.ModTime(),
+		}
+
+		if metaData, err := os.ReadFile(ls.path(entry.Name()) + localMetaSuffix); err == nil {
+			if err := json.Unmarshal(metaData, &object.Metadata); err != nil {
+				return nil, fmt.Errorf("obje metadata'sı parse edilemedi '%s': %w", entry.Name(), err)
+			}
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+func (ls *LocalStore) URI(objectName string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(ls.path(objectName))}).String()
+}
+
+// yerel dosyalar imzalanamaz; süre sınırı olmayan file:// adresi döner
+func (ls *LocalStore) SignedURL(objectName string, expiry time.Duration) (string, error) {
+	if _, err := os.Stat(ls.path(objectName)); err != nil {
+		return "", fmt.Errorf("obje bulunamadı '%s': %w", objectName, err)
+	}
+	return ls.URI(objectName), nil
+}
+
+func (ls *LocalStore) Close() error {
+	return nil
+}
+
+func (ls *LocalStore) path(objectName string) string {
+	return filepath.Join(ls.dir, filepath.Base(objectName))
+}
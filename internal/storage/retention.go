@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"spt2/pkg/models"
+)
+
+// saklama politikaları (config: retention_policy)
+const (
+	RetentionDelete  = "delete"  // deşifreden hemen sonra sil
+	RetentionDays    = "days"    // retention_days gün sakla, sonra `spt2 gc` siler
+	RetentionForever = "forever" // hiç silme
+)
+
+// araç tarafından yüklenen objelere eklenen metadata anahtarları
+const (
+	MetaCreatedBy = "spt2-created-by"
+	MetaSource    = "spt2-source"
+	MetaRetention = "spt2-retention"
+	MetaExpiresAt = "spt2-expires-at"
+)
+
+// RetentionMetadata - yüklenecek obje için saklama metadata'sı
+//
+// "days" politikasında son saklama zamanı (RFC3339) metadata'ya yazılır;
+// gc bu değere bakarak objeyi siler.
+func RetentionMetadata(cfg *models.AppConfig, sourcePath string, now time.Time) map[string]string {
+	metadata := map[string]string{
+		MetaCreatedBy: "spt2",
+		MetaSource:    filepath.Base(sourcePath),
+		MetaRetention: cfg.RetentionPolicy,
+	}
+
+	if cfg.RetentionPolicy == RetentionDays {
+		metadata[MetaExpiresAt] = now.AddDate(0, 0, cfg.RetentionDays).UTC().Format(time.RFC3339)
+	}
+
+	return metadata
+}
+
+// IsToolObject - obje spt2 tarafından mı yüklendi (metadata veya isim kalıbı)
+func (o ObjectInfo) IsToolObject() bool {
+	if o.Metadata[MetaCreatedBy] == "spt2" {
+		return true
+	}
+	_, _, ok := ParseObjectName(o.Name)
+	return ok
+}
+
+// CreatedAt - isimdeki unix zamanı, yoksa depolamanın bildirdiği zaman
+func (o ObjectInfo) CreatedAt() time.Time {
+	if createdAt, _, ok := ParseObjectName(o.Name); ok {
+		return createdAt
+	}
+	return o.Created
+}
+
+// ExpiresAt - metadata'daki son saklama zamanı
+func (o ObjectInfo) ExpiresAt() (time.Time, bool) {
+	value, ok := o.Metadata[MetaExpiresAt]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
+
+// GCOptions - CollectGarbage ayarları
+type GCOptions struct {
+	All       bool          // saklama politikasına bakmadan tüm araç objelerini sil
+	OlderThan time.Duration // expires-at metadata'sı olmayan objeler için yaş sınırı
+	DryRun    bool          // sadece listele, silme
+	Now       time.Time
+}
+
+// GCEntry - gc'nin bir obje için verdiği karar
+type GCEntry struct {
+	Object  ObjectInfo
+	Purge   bool   // silinecek mi
+	Reason  string // kararın nedeni
+	Deleted bool   // gerçekten silindi mi (DryRun'da hep false)
+	Err     error
+}
+
+// CollectGarbage - araç tarafından yüklenen objeleri listeler ve süresi
+// dolanları siler
+//
+// KARAR SIRASI:
+// 1. opts.All → sil
+// 2. retention=forever metadata'sı → sakla
+// 3. retention=delete metadata'sı → sil (deşifreden sonra silinememiş artık)
+// 4. expires-at metadata'sı → süresi geçtiyse sil
+// 5. metadata yok (eski objeler) → opts.OlderThan'dan eskiyse sil
+//
+// Tek bir objenin silinememesi diğerlerini durdurmaz; hata GCEntry.Err'e yazılır.
+func CollectGarbage(ctx context.Context, store ObjectStore, opts GCOptions) ([]GCEntry, error) {
+	objects, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	var entries []GCEntry
+	for _, object := range objects {
+		if !object.IsToolObject() {
+			continue
+		}
+
+		entry := GCEntry{Object: object}
+		entry.Purge, entry.Reason = gcDecision(object, opts)
+
+		if entry.Purge && !opts.DryRun {
+			if err := store.Delete(ctx, object.Name); err != nil {
+				entry.Err = err
+			} else {
+				entry.Deleted = true
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func gcDecision(object ObjectInfo, opts GCOptions) (bool, string) {
+	if opts.All {
+		return true, "-all"
+	}
+
+	if object.Metadata[MetaRetention] == RetentionForever {
+		return false, "saklama politikası: forever"
+	}
+
+	// "delete" objeleri deşifreden hemen sonra silinir; hâlâ duruyorsa silme başarısız olmuştur
+	if object.Metadata[MetaRetention] == RetentionDelete {
+		return true, "saklama politikası: delete (deşifreden sonra silinememiş)"
+	}
+
+	if expiresAt, ok := object.ExpiresAt(); ok {
+		if opts.Now.After(expiresAt) {
+			return true, fmt.Sprintf("süresi doldu (%s)", expiresAt.Format("2006-01-02 15:04"))
+		}
+		return false, fmt.Sprintf("%s tarihine kadar saklanıyor", expiresAt.Format("2006-01-02 15:04"))
+	}
+
+	age := opts.Now.Sub(object.CreatedAt())
+	if opts.OlderThan > 0 && age > opts.OlderThan {
+		return true, fmt.Sprintf("%.0f saat önce oluşturuldu", age.Hours())
+	}
+	return false, fmt.Sprintf("%.0f saat önce oluşturuldu", age.Hours())
+}
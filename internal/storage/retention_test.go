@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var gcNow = time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+
+// ObjectName biçiminde, gcNow'dan age kadar önce oluşturulmuş obje adı
+func agedObjectName(name string, age time.Duration) string {
+	return fmt.Sprintf("%d-%s", gcNow.Add(-age).Unix(), name)
+}
+
+func TestGCDecision(t *testing.T) {
+	expired := gcNow.Add(-time.Hour).Format(time.RFC3339)
+	unexpired := gcNow.Add(time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name   string
+		object ObjectInfo
+		opts   GCOptions
+		want   bool
+	}{
+		{"forever", ObjectInfo{Name: agedObjectName("a.flac", 1000*time.Hour), Metadata: map[string]string{MetaRetention: RetentionForever}}, GCOptions{OlderThan: time.Hour}, false},
+		{"forever -all", ObjectInfo{Name: agedObjectName("a.flac", time.Minute), Metadata: map[string]string{MetaRetention: RetentionForever}}, GCOptions{All: true}, true},
+		{"süresi dolmuş", ObjectInfo{Name: agedObjectName("b.flac", 48*time.Hour), Metadata: map[string]string{MetaRetention: RetentionDays, MetaExpiresAt: expired}}, GCOptions{}, true},
+		{"süresi dolmamış", ObjectInfo{Name: agedObjectName("c.flac", 1000*time.Hour), Metadata: map[string]string{MetaRetention: RetentionDays, MetaExpiresAt: unexpired}}, GCOptions{OlderThan: time.Hour}, false},
+		{"delete artığı, yeni", ObjectInfo{Name: agedObjectName("d.flac", time.Minute), Metadata: map[string]string{MetaRetention: RetentionDelete}}, GCOptions{OlderThan: 168 * time.Hour}, true},
+		{"metadata yok, eski", ObjectInfo{Name: agedObjectName("e.flac", 72*time.Hour)}, GCOptions{OlderThan: 48 * time.Hour}, true},
+		{"metadata yok, yeni", ObjectInfo{Name: agedObjectName("f.flac", 24*time.Hour)}, GCOptions{OlderThan: 48 * time.Hour}, false},
+		{"metadata yok, yaş sınırı yok", ObjectInfo{Name: agedObjectName("g.flac", 1000*time.Hour)}, GCOptions{}, false},
+	}
+	for _, test := range tests {
+		test.opts.Now = gcNow
+		if got, reason := gcDecision(test.object, test.opts); got != test.want {
+			t.Errorf("%s: silinsin mi = %v (%s), beklenen %v", test.name, got, reason, test.want)
+		}
+	}
+}
+
+func TestCollectGarbage(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore("spt2")
+	localPath := filepath.Join(t.TempDir(), "ders.flac")
+	if err := os.WriteFile(localPath, []byte("fLaC"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := map[string]map[string]string{
+		agedObjectName("sakla.flac", 1000*time.Hour): {MetaCreatedBy: "spt2", MetaRetention: RetentionForever},
+		agedObjectName("artik.flac", time.Minute):    {MetaCreatedBy: "spt2", MetaRetention: RetentionDelete},
+		agedObjectName("dolmus.flac", 48*time.Hour):  {MetaCreatedBy: "spt2", MetaRetention: RetentionDays, MetaExpiresAt: gcNow.Add(-time.Hour).Format(time.RFC3339)},
+		agedObjectName("eski.flac", 72*time.Hour):    nil,
+		agedObjectName("yeni.flac", time.Hour):       nil,
+		"baska-arac/kayit.wav":                       nil, // araç objesi değil, dokunulmamalı
+	}
+	for name, metadata := range objects {
+		if _, err := store.Put(ctx, localPath, name, metadata); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := GCOptions{OlderThan: 48 * time.Hour, DryRun: true, Now: gcNow}
+	entries, err := CollectGarbage(ctx, store, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("%d araç objesi, beklenen 5", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Deleted {
+			t.Errorf("dry-run %s objesini sildi", entry.Object.Name)
+		}
+	}
+
+	opts.DryRun = false
+	if _, err := CollectGarbage(ctx, store, opts); err != nil {
+		t.Fatal(err)
+	}
+	remaining, err := store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, object := range remaining {
+		names = append(names, object.Name)
+	}
+	want := []string{agedObjectName("sakla.flac", 1000*time.Hour), agedObjectName("yeni.flac", time.Hour), "baska-arac/kayit.wav"}
+	if len(names) != len(want) {
+		t.Fatalf("kalan objeler = %v, beklenen %v", names, want)
+	}
+	for _, name := range want {
+		if _, ok := store.Object(name); !ok {
+			t.Errorf("%s silinmemeliydi (kalanlar: %v)", name, names)
+		}
+	}
+}
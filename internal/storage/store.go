@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"spt2/pkg/models"
+)
+
+// ObjectStore - FLAC dosyalarının tanıma motoruna ulaştırıldığı depolama katmanı
+//
+// IMPLEMENTASYONLAR:
+// - GCSStore:    Google Cloud Storage bucket'ı (gs://bucket/obj)
+// - LocalStore:  yerel bir dizin (file:///dizin/obj)
+// - MemoryStore: bellekte tutulan sahte depolama (mem://bucket/obj), testler için
+type ObjectStore interface {
+	// Put - yerel dosyayı objectName adıyla (ve verilen metadata ile) yükler,
+	// URI'sini döndürür
+	Put(ctx context.Context, localFilePath, objectName string, metadata map[string]string) (string, error)
+	// Delete - objeyi siler
+	Delete(ctx context.Context, objectName string) error
+	// List - depodaki tüm objeleri listeler
+	List(ctx context.Context) ([]ObjectInfo, error)
+	// URI - objenin tanıma motoruna verilecek adresi
+	URI(objectName string) string
+	// SignedURL - objeye expiry süresince geçerli, imzalı okuma adresi
+	SignedURL(objectName string, expiry time.Duration) (string, error)
+	Close() error
+}
+
+// ObjectInfo - List ile dönen obje bilgisi
+type ObjectInfo struct {
+	Name     string
+	URI      string
+	Size     int64
+	Created  time.Time
+	Metadata map[string]string
+}
+
+// NewObjectStore - config'deki `storage_backend` alanına göre depolamayı oluşturur
+func NewObjectStore(ctx context.Context, cfg *models.AppConfig) (ObjectStore, error) {
+	switch cfg.StorageBackend {
+	case "", "gcs":
+		store, err := NewGCSStore(ctx, cfg.GCSBucket, cfg.GoogleCredentialsPath)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "local":
+		store, err := NewLocalStore(cfg.StorageLocalDir)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "memory":
+		return NewMemoryStore("spt2"), nil
+	default:
+		return nil, fmt.Errorf("bilinmeyen storage backend: %s", cfg.StorageBackend)
+	}
+}
+
+// ObjectName - yüklenecek dosya için benzersiz obje adı (<unix>-<dosya_adı>)
+//
+// Pipeline'ın FLAC'leri iş ID'siyle adlandırıldığından aynı saniyede yüklenen
+// aynı adlı kaynaklar da farklı objelere yazılır (1718000000-20240610-150405-ders.flac).
+func ObjectName(localFilePath string) string {
+	return fmt.Sprintf("%d-%s", time.Now().Unix(), filepath.Base(localFilePath))
+}
+
+// ParseObjectName - ObjectName ile üretilmiş adı çözer
+//
+// "1718000000-ders.flac" → (2024-06-10 ..., "ders.flac", true).
+// Araç tarafından oluşturulmamış objeler için ok=false döner.
+func ParseObjectName(objectName string) (createdAt time.Time, fileName string, ok bool) {
+	prefix, rest, found := strings.Cut(objectName, "-")
+	if !found || rest == "" || !strings.HasSuffix(rest, ".flac") {
+		return time.Time{}, "", false
+	}
+
+	unix, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil || unix <= 0 {
+		return time.Time{}, "", false
+	}
+
+	return time.Unix(unix, 0), rest, true
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spt2/pkg/models"
+)
+
+// LocalStore ve MemoryStore aynı ObjectStore davranışını göstermeli
+func TestObjectStores(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "ders.flac")
+	if err := os.WriteFile(source, []byte("fLaC-veri"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	local, err := NewLocalStore(filepath.Join(t.TempDir(), "depo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stores := map[string]struct {
+		store  ObjectStore
+		scheme string
+	}{
+		"local":  {local, "file://"},
+		"memory": {NewMemoryStore("spt2"), "mem://spt2/"},
+	}
+
+	ctx := context.Background()
+	for name, test := range stores {
+		store := test.store
+		uri, err := store.Put(ctx, source, "1718000000-ders.flac", map[string]string{MetaRetention: RetentionDelete})
+		if err != nil {
+			t.Fatalf("%s Put: %v", name, err)
+		}
+		if !strings.HasPrefix(uri, test.scheme) || !strings.HasSuffix(uri, "1718000000-ders.flac") || uri != store.URI("1718000000-ders.flac") {
+			t.Errorf("%s URI = %s", name, uri)
+		}
+		if _, err := store.Put(ctx, source, "1718000001-ders.flac", nil); err != nil {
+			t.Fatalf("%s Put: %v", name, err)
+		}
+
+		objects, err := store.List(ctx)
+		if err != nil || len(objects) != 2 {
+			t.Fatalf("%s List: %d obje (%v), beklenen 2 (metadata dosyaları sayılmaz)", name, len(objects), err)
+		}
+		for _, object := range objects {
+			if object.Size != int64(len("fLaC-veri")) {
+				t.Errorf("%s %s boyutu %d", name, object.Name, object.Size)
+			}
+			if object.Name == "1718000000-ders.flac" && object.Metadata[MetaRetention] != RetentionDelete {
+				t.Errorf("%s metadata = %v", name, object.Metadata)
+			}
+		}
+
+		if _, err := store.SignedURL("1718000000-ders.flac", time.Hour); err != nil {
+			t.Errorf("%s SignedURL: %v", name, err)
+		}
+		if _, err := store.SignedURL("yok.flac", time.Hour); err == nil {
+			t.Errorf("%s: olmayan obje için SignedURL hata vermeli", name)
+		}
+
+		if err := store.Delete(ctx, "1718000000-ders.flac"); err != nil {
+			t.Fatalf("%s Delete: %v", name, err)
+		}
+		if err := store.Delete(ctx, "1718000000-ders.flac"); err == nil {
+			t.Errorf("%s: silinmiş obje ikinci kez silinememeli", name)
+		}
+		if objects, _ := store.List(ctx); len(objects) != 1 {
+			t.Errorf("%s: silme sonrası %d obje, beklenen 1", name, len(objects))
+		}
+	}
+}
+
+// obje adındaki dizin kısmı depo dışına yazdırmamalı
+func TestLocalStoreKeepsObjectsInDir(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocalStore(filepath.Join(dir, "depo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := filepath.Join(dir, "ders.flac")
+	if err := os.WriteFile(source, []byte("veri"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Put(context.Background(), source, "../kacak.flac", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "kacak.flac")); err == nil {
+		t.Error("obje depo dizini dışına yazıldı")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "depo", "kacak.flac")); err != nil {
+		t.Errorf("obje depo dizininde değil: %v", err)
+	}
+}
+
+func TestNewObjectStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewObjectStore(ctx, &models.AppConfig{StorageBackend: "memory"})
+	if _, ok := store.(*MemoryStore); err != nil || !ok {
+		t.Errorf("memory: %T (%v)", store, err)
+	}
+	store, err = NewObjectStore(ctx, &models.AppConfig{StorageBackend: "local", StorageLocalDir: t.TempDir()})
+	if _, ok := store.(*LocalStore); err != nil || !ok {
+		t.Errorf("local: %T (%v)", store, err)
+	}
+	if _, err := NewObjectStore(ctx, &models.AppConfig{StorageBackend: "s3"}); err == nil {
+		t.Error("bilinmeyen backend için hata bekleniyordu")
+	}
+}
+
+func TestParseObjectName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		ok       bool
+	}{
+		{"1718000000-ders.flac", "ders.flac", true},
+		{"1718000000-20240610-150405-ders.flac", "20240610-150405-ders.flac", true},
+		{"1718000000-ders.wav", "", false},
+		{"ders.flac", "", false},
+		{"abc-ders.flac", "", false},
+		{"0-ders.flac", "", false},
+		{"1718000000-", "", false},
+	}
+	for _, test := range tests {
+		createdAt, fileName, ok := ParseObjectName(test.name)
+		if ok != test.ok || fileName != test.fileName {
+			t.Errorf("ParseObjectName(%q) = %q, %t; beklenen %q, %t", test.name, fileName, ok, test.fileName, test.ok)
+		}
+		if ok && createdAt.Unix() != 1718000000 {
+			t.Errorf("ParseObjectName(%q) zamanı %v", test.name, createdAt)
+		}
+	}
+
+	name := ObjectName("/tmp/çıktı/ders.flac")
+	if _, fileName, ok := ParseObjectName(name); !ok || fileName != "ders.flac" {
+		t.Errorf("ObjectName çıktısı çözülemedi: %s", name)
+	}
+}
@@ -5,9 +5,15 @@ import "time"
 type AudioMetadata struct {
     //dosya bilgileri
 	FilePath      		string 		 `json:"file_path"`
-    OriginalFormat 		string		 `json:"original_format"`  // "mp3", "wav", "m4a"
+    OriginalFormat 		string		 `json:"original_format"`  // "mp3", "wav", "m4a" (içerikten tespit edilen)
+    Container			string		 `json:"container"`        // magic bytes ile tespit edilen container
+    FormatWarning		string		 `json:"format_warning,omitempty"` // uzantı ile içerik uyuşmazlığı
     FileSize      		int64  		 `json:"file_size"`        // Byte cinsinden
     ConvertedPath 		string 		 `json:"converted_path"`   // FLAC dosya yolu
+    ConvertedSampleRate	int			 `json:"converted_sample_rate"` // FLAC header'ından okunan Hz
+    ConvertedChannels	int			 `json:"converted_channels"`    // FLAC header'ından okunan kanal sayısı
+    ConvertedDuration	float64		 `json:"converted_duration"`    // FLAC header'ından okunan süre (kırpıldıysa Duration'dan kısa)
+    FilterGraph			string		 `json:"filter_graph,omitempty"` // dönüşümde uygulanan ffmpeg ön işleme zinciri (-af)
   
 	//ses özellikleri
 	Duration      		float64 	`json:"duration"`         // Saniye cinsinden
@@ -15,6 +21,23 @@ type AudioMetadata struct {
     Channels      		int     	`json:"channels"`         // 1=mono, 2=stereo
     BitDepth      		int     	`json:"bit_depth"`        // 16, 24, 32 bit
 	Codec				string		`json:"codec"`			  //"aac", "mp3", "pcm"
+	BitRate				int64		`json:"bit_rate"`         // bit/saniye
+	AudioStreams		int			`json:"audio_streams"`    // dosyadaki ses akışı sayısı
+	AudioTracks			[]AudioTrack `json:"audio_tracks,omitempty"`
+	SelectedTrack		int			`json:"selected_track"`   // FLAC'e çıkarılan ses izi (AudioTracks içindeki sıra)
+
+	//video özellikleri (video container'larında)
+	HasVideo			bool		`json:"has_video"`
+	VideoCodec			string		`json:"video_codec,omitempty"`  // "h264", "vp9", ...
+	VideoDuration		float64		`json:"video_duration,omitempty"` // Saniye cinsinden
+	FrameRate			float64		`json:"frame_rate,omitempty"`   // fps (örn: 29.97)
+
+	//konuşma tespiti (enable_vad açıkken, bkz. audio.DetectSpeech)
+	SpeechRatio			float64			`json:"speech_ratio,omitempty"`    // konuşma süresi / toplam süre
+	SpeechDuration		float64			`json:"speech_duration,omitempty"` // saniye
+	SpeechRegions		[]SpeechRegion	`json:"speech_regions,omitempty"`
+	TrimStart			float64			`json:"trim_start,omitempty"` // trim_silence: FLAC'e alınan aralık (kaynak ses zamanı)
+	TrimEnd				float64			`json:"trim_end,omitempty"`   // 0 ise kırpılmadı
 
 	//hata ve durum yönetimleri
 	IsValid				bool		`json:"is_valid"`
@@ -23,3 +46,23 @@ type AudioMetadata struct {
 	CreatedAt			time.Time 	`json:"created_at"`
 
 }
+
+// sesin konuşma içeren bir aralığı (saniye)
+type SpeechRegion struct {
+	Start		float64		`json:"start"`
+	End			float64		`json:"end"`
+}
+
+// dosyadaki bir ses izi (ffprobe stream bilgisi)
+type AudioTrack struct {
+	Index		int		`json:"index"`        // ses izleri arasındaki sıra (ffmpeg -map 0:a:N)
+	StreamIndex	int		`json:"stream_index"` // dosyadaki stream numarası
+	Codec		string	`json:"codec"`
+	Language	string	`json:"language,omitempty"` // ISO 639-2 etiketi (örn: "tur", "eng")
+	Title		string	`json:"title,omitempty"`
+	SampleRate	int		`json:"sample_rate"`
+	Channels	int		`json:"channels"`
+	BitDepth	int		`json:"bit_depth"`
+	BitRate		int64	`json:"bit_rate"`
+	Default		bool	`json:"default"` // container'da varsayılan iz olarak işaretli
+}
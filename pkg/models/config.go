@@ -3,15 +3,42 @@ package models
 // AppConfig - Uygulama konfigürasyonu (Viper ve Validator ile çalışır)
 type AppConfig struct {
     // Google Cloud Ayarları
-    GoogleCredentialsPath string `mapstructure:"google_credentials_path" validate:"required,file"`
-    ProjectID             string `mapstructure:"project_id" validate:"required"`
-    GCSBucket             string `mapstructure:"gcs_bucket" validate:"required"`
+    // Credentials ve ProjectID yalnızca Google servisleri kullanılıyorsa zorunlu
+    // (google backend veya gcs storage), bkz. config.validateGoogleSettings
+    GoogleCredentialsPath string `mapstructure:"google_credentials_path" validate:"omitempty,file"`
+    ProjectID             string `mapstructure:"project_id"`
+    GCSBucket             string `mapstructure:"gcs_bucket" validate:"required_if=StorageBackend gcs"`
+    
+    // Depolama: "gcs", "local" (yerel dizin) veya "memory" (testler için);
+    // verilmezse fake backend'te "memory", diğerlerinde "gcs"
+    StorageBackend  string `mapstructure:"storage_backend" validate:"required,oneof=gcs local memory"`
+    StorageLocalDir string `mapstructure:"storage_local_dir" validate:"required_if=StorageBackend local"`
+    
+    // Yüklenen objelerin saklanması: "delete" (deşifreden sonra sil),
+    // "days" (RetentionDays gün sakla, `spt2 gc` siler) veya "forever" (varsayılan)
+    RetentionPolicy string `mapstructure:"retention_policy" validate:"required,oneof=delete days forever"`
+    RetentionDays   int    `mapstructure:"retention_days" validate:"omitempty,min=1,max=3650"`
+    
+    // Tanıma Motoru: "google" (Speech-to-Text v1) veya "fake" (testler için)
+    Backend        string `mapstructure:"backend" validate:"required,oneof=google fake"`
+    FakeResultFile string `mapstructure:"fake_result_file" validate:"omitempty,file"` // fake backend'in döndüreceği TranscriptionResult JSON'u
+    SpeechEndpoint string `mapstructure:"speech_endpoint"`                          // örn: "127.0.0.1:9090" (cmd/fakespeech), boşsa Google
     
     // API Temel Ayarları
-    LanguageCode string `mapstructure:"language_code" validate:"required,oneof=en-US en-GB tr-TR de-DE fr-FR es-ES"`
+    // LanguageCode "auto" ise dil AlternativeLanguageCodes arasından (en az 2)
+    // her sonuç için ayrı tespit edilir (en fazla 4; sabit dilde en fazla 3 alternatif),
+    // bkz. language.RecognitionLanguages
+    LanguageCode             string   `mapstructure:"language_code" validate:"required,oneof=auto en-US en-GB tr-TR de-DE fr-FR es-ES"`
+    AlternativeLanguageCodes []string `mapstructure:"alternative_language_codes" validate:"omitempty,max=4,dive,oneof=en-US en-GB tr-TR de-DE fr-FR es-ES"`
     Model        string `mapstructure:"model" validate:"required,oneof=default video telephony medical command_and_search"`
     UseEnhanced  bool   `mapstructure:"use_enhanced"`
     
+    // Çok kanallı tanıma: her kanal (örn. kişi başına bir mikrofon) ayrı tanınır
+    // ve kanal numarası konuşmacı olur; açıkken diarization istenmez.
+    // ChannelNames sırayla 1., 2., ... kanalın adıdır (örn: ["Muhabir", "Konuk"])
+    MultiChannel bool     `mapstructure:"multi_channel"`
+    ChannelNames []string `mapstructure:"channel_names" validate:"omitempty,max=8,dive,required"`
+    
     // Diarization (Konuşmacı Ayırma)
     EnableDiarization bool `mapstructure:"enable_diarization"`
     MinSpeakers       int  `mapstructure:"min_speakers" validate:"omitempty,min=1,max=6"`
@@ -22,6 +49,18 @@ type AppConfig struct {
     KeywordsFile       string  `mapstructure:"keywords_file" validate:"omitempty"`
     BoostValue         float64 `mapstructure:"boost_value" validate:"omitempty,min=0,max=20"`
     
+    // Anahtar kelime arama (keywords_file): yazım farklarına izin ve bağlam genişliği
+    KeywordFuzzy        bool `mapstructure:"keyword_fuzzy"`
+    KeywordMaxEdits     int  `mapstructure:"keyword_max_edits" validate:"omitempty,min=1,max=3"`
+    KeywordContextWords int  `mapstructure:"keyword_context_words" validate:"min=0,max=30"`
+    // Türkçe ek çözümlemesi: "auto" (language_code tr ise), "turkish" veya "none"
+    KeywordMorphology string `mapstructure:"keyword_morphology" validate:"required,oneof=auto turkish none"`
+    
+    // Dil profilleri: dile özel speech contexts ve keywords dosyaları,
+    // örn: {"tr-TR": {"speech_contexts_file": "...", "keywords_file": "..."}}
+    // Anahtarlar büyük/küçük harf duyarsızdır (viper anahtarları küçültür).
+    LanguageProfiles map[string]LanguageProfile `mapstructure:"language_profiles" validate:"dive"`
+    
     // Runtime'da TXT dosyalarından yüklenir (JSON'da yok)
     SpeechContexts []string `mapstructure:"-" json:"-"`
     Keywords       []string `mapstructure:"-" json:"-"`
@@ -36,18 +75,94 @@ type AppConfig struct {
     MaxAlternatives int     `mapstructure:"max_alternatives" validate:"omitempty,min=1,max=30"`
     ProfanityFilter bool    `mapstructure:"profanity_filter"`
     
+    // MinConfidence altındaki kelimelerin çıktı formatına göre ele alınışı:
+    // "keep", "flag" (işaretle) veya "filter" (çıkar), örn: {"srt": "filter", "txt": "flag"}
+    LowConfidence map[string]string `mapstructure:"low_confidence" validate:"dive,keys,oneof=json srt txt vtt,endkeys,oneof=keep flag filter"`
+    
+    // Kısa dosyalar için senkron (inline) tanıma: "auto", "always" veya "never"
+    SyncRecognition string  `mapstructure:"sync_recognition" validate:"required,oneof=auto always never"`
+    SyncMaxDuration float64 `mapstructure:"sync_max_duration" validate:"omitempty,gt=0,max=60"` // saniye (API limiti 60 sn)
+    
     // Ses İşleme Ayarları
     TargetSampleRate int  `mapstructure:"target_sample_rate" validate:"required,min=8000,max=48000"`
-    ConvertToMono    bool `mapstructure:"convert_to_mono"`
+    ConvertToMono    bool `mapstructure:"convert_to_mono"` // false yalnızca multi_channel ile
     ChunkSize        int  `mapstructure:"chunk_size" validate:"required,min=1024,max=65536"`
     
+    // Uzun Ses Bölme: "auto" modunda chunk_max_duration'dan uzun sesler sessizliklerden
+    // parçalara bölünür, parçalar paralel tanınıp tek sonuçta birleştirilir; "never" kapatır
+    Chunking           string  `mapstructure:"chunking" validate:"required,oneof=auto never"`
+    ChunkMaxDuration   float64 `mapstructure:"chunk_max_duration" validate:"required,min=30,max=28800"` // saniye, örtüşme dahil (API limiti 480 dk)
+    ChunkOverlap       float64 `mapstructure:"chunk_overlap" validate:"min=0,max=5"`                    // bölme noktasının iki yanına eklenen süre (saniye)
+    ChunkWorkers       int     `mapstructure:"chunk_workers" validate:"required,min=1,max=16"`          // eşzamanlı tanınan parça sayısı
+    SilenceThreshold   float64 `mapstructure:"silence_threshold" validate:"min=-90,max=0"`              // dB, bu seviyenin altı sessizlik sayılır
+    SilenceMinDuration float64 `mapstructure:"silence_min_duration" validate:"required,gt=0,max=10"`    // saniye
+    
+    // Konuşma Tespiti (VAD): dönüştürmeden önce sesin konuşma bölgeleri bulunur;
+    // konuşma yoksa API'a gidilmez, parça sınırları bu bölgelerden seçilir
+    EnableVAD    bool    `mapstructure:"enable_vad"`
+    VADThreshold float64 `mapstructure:"vad_threshold" validate:"required,gt=0,max=40"` // gürültü tabanının kaç dB üstü konuşma sayılır
+    VADMinSpeech float64 `mapstructure:"vad_min_speech" validate:"min=0,max=5"`         // saniye; daha kısa bölgeler atılır
+    VADPadding   float64 `mapstructure:"vad_padding" validate:"min=0,max=2"`            // bölgelerin iki yanına eklenen süre (saniye)
+    TrimSilence  bool    `mapstructure:"trim_silence"`                                  // baştaki ve sondaki sessizlik FLAC'e alınmaz (enable_vad gerekir)
+    
+    // Ön İşleme: FLAC dönüşümünde sırasıyla trim_silence → high-pass → gürültü azaltma → loudnorm
+    PreprocessHighpass        float64 `mapstructure:"preprocess_highpass" validate:"omitempty,min=20,max=1000"`                    // Hz, 0 ise kapalı
+    PreprocessDenoise         string  `mapstructure:"preprocess_denoise" validate:"required,oneof=none afftdn arnndn"`
+    PreprocessDenoiseStrength float64 `mapstructure:"preprocess_denoise_strength" validate:"omitempty,min=0.01,max=97"`                           // afftdn gürültü azaltma (dB)
+    PreprocessDenoiseModel    string  `mapstructure:"preprocess_denoise_model" validate:"omitempty,file"`                          // arnndn model dosyası (.rnnn)
+    PreprocessLoudnorm        bool    `mapstructure:"preprocess_loudnorm"`                                                         // EBU R128 loudness normalizasyonu (iki geçiş)
+    PreprocessLoudnormTarget  float64 `mapstructure:"preprocess_loudnorm_target" validate:"min=-70,max=-5"`                        // LUFS (EBU R128: -23)
+    
+    // Birden fazla ses izi olan (video) dosyalarda iz seçimi:
+    // AudioTrack >= 0 ise o sıradaki iz, değilse AudioLanguage etiketi eşleşen iz
+    // (örn: "tr", "eng"); ikisi de boşsa varsayılan iz kullanılır
+    AudioTrack    int    `mapstructure:"audio_track" validate:"min=-1"`
+    AudioLanguage string `mapstructure:"audio_language"`
+    
+    // Sonuç Cache'i: aynı ses + aynı tanıma ayarları için API tekrar çağrılmaz
+    // (varsayılan kapalı; CacheDir verilmezse <output_dir>/cache)
+    EnableCache bool   `mapstructure:"enable_cache"`
+    CacheDir    string `mapstructure:"cache_dir" validate:"required_if=EnableCache true"`
+    
+    // Toplu İşlem (batch komutu): eşzamanlı işlenecek dosya sayısı
+    BatchWorkers int `mapstructure:"batch_workers" validate:"required,min=1,max=32"`
+    
     // Çıktı Ayarları
     OutputDir    string `mapstructure:"output_dir" validate:"required"`
     GenerateJSON bool   `mapstructure:"generate_json"`
     GenerateSRT  bool   `mapstructure:"generate_srt"`
     GenerateTXT  bool   `mapstructure:"generate_txt"`
+    GenerateVTT  bool   `mapstructure:"generate_vtt"`
+    
+    // Altyazı Bölümleme (SRT ve VTT)
+    SubtitleMaxCharsPerLine int     `mapstructure:"subtitle_max_chars_per_line" validate:"required,min=10,max=100"`
+    SubtitleMaxLines        int     `mapstructure:"subtitle_max_lines" validate:"required,min=1,max=4"`
+    SubtitleMinDuration     float64 `mapstructure:"subtitle_min_duration" validate:"min=0,max=10"`                                   // saniye
+    SubtitleMaxDuration     float64 `mapstructure:"subtitle_max_duration" validate:"required,gt=0,max=30,gtefield=SubtitleMinDuration"` // saniye
+    SubtitleMaxCPS          float64 `mapstructure:"subtitle_max_cps" validate:"required,gt=0,max=50"`                                // okuma hızı (karakter/sn)
+    SubtitlePauseThreshold  float64 `mapstructure:"subtitle_pause_threshold" validate:"required,gt=0,max=10"`                        // bu süreden uzun sessizlikte yeni altyazı
+    SubtitleSplitOnSpeaker  bool    `mapstructure:"subtitle_split_on_speaker"`                                                     // konuşmacı değişince yeni altyazı
+    
+    // WebVTT Ayarları (generate_vtt açıkken)
+    VTTPosition  string `mapstructure:"vtt_position" validate:"omitempty,endswith=%"` // örn: "50%"
+    VTTLine      string `mapstructure:"vtt_line"`                                      // örn: "85%" veya "-2"
+    VTTAlign     string `mapstructure:"vtt_align" validate:"omitempty,oneof=start center end left right"`
+    VTTVoiceTags bool   `mapstructure:"vtt_voice_tags"` // <v Konuşmacı N> etiketleri (diarization gerekir)
+    VTTKaraoke   bool   `mapstructure:"vtt_karaoke"`    // kelime bazlı <00:00:01.000> zaman damgaları
+    VTTNote      string `mapstructure:"vtt_note"`
+    VTTStyle     string `mapstructure:"vtt_style"`      // STYLE bloğu (CSS)
     
     // Logging
     EnableLogging bool   `mapstructure:"enable_logging"`
     LogLevel      string `mapstructure:"log_level" validate:"omitempty,oneof=debug info warn error"`
 }
+
+// LanguageProfile - bir dile ait kelime dosyaları (config: language_profiles)
+type LanguageProfile struct {
+    SpeechContextsFile string `mapstructure:"speech_contexts_file" validate:"omitempty,file"`
+    KeywordsFile       string `mapstructure:"keywords_file" validate:"omitempty,file"`
+    
+    // Runtime'da TXT dosyalarından yüklenir
+    SpeechContexts []string `mapstructure:"-" json:"-"`
+    Keywords       []string `mapstructure:"-" json:"-"`
+}
@@ -5,13 +5,16 @@ import "time"
 //tüm deşifre işleminin sonucu için
 type TranscriptionResult struct {
 	Transcript		string		   `json:"transcript"`
-	Confidence		float64		   `json:"confidence"`
+	Confidence		float64		   `json:"confidence"` // segment güvenlerinin süreye göre ağırlıklı ortalaması
 	LanguageCode 	string		   `json:"language_code"`
 	Words			[]WordInfo	   `json:"words"`
+	Segments		[]Segment	   `json:"segments,omitempty"` // API'ın döndürdüğü her sonuç
 	AudioDuration	float64		   `json:"file_length"`
 	ProcessedAt		time.Time 	   `json:"processed_time"`
 	Speakers		[]SpeakerInfo  `json:"speakers,omitempty"` //opsiyonel olduğundan omiempty
 	KeywordMatches	[]KeywordMatch `json:"keyword_matches,omitempty"`
+	FrameRate		float64		   `json:"frame_rate,omitempty"`     // kaynak video ise: altyazılar karelere hizalanır
+	VideoDuration	float64		   `json:"video_duration,omitempty"` // kaynak video ise: altyazılar bu süreyi aşmaz
 
 }
 
@@ -22,26 +25,51 @@ type WordInfo struct {
 	EndTime			float64			`json:"end_time"`
 	Confidence		float64			`json:"confidence"`
 	SpeakerTag		int32			`json:"speaker_tag,omitempty"`
+	LowConfidence	bool			`json:"low_confidence,omitempty"` // güven min_confidence'ın altında
+	ChannelTag		int32			`json:"channel_tag,omitempty"`    // çok kanallı tanımada kelimenin kanalı
 
 }
 
+//API sonucunun bir parçası (speechpb.SpeechRecognitionResult)
+type Segment struct {
+	Transcript		string			`json:"transcript"`
+	Confidence		float64			`json:"confidence"`
+	Start			float64			`json:"start_time"`
+	End				float64			`json:"end_time"`      // ResultEndTime
+	ChannelTag		int32			`json:"channel_tag,omitempty"`
+	LanguageCode	string			`json:"language_code,omitempty"` // API'ın tespit ettiği dil
+	Alternatives	[]Alternative	`json:"alternatives,omitempty"` // ilk alternatif dışındaki tahminler
+}
+
+//bir sonuç için alternatif tahmin (max_alternatives > 1)
+type Alternative struct {
+	Transcript		string			`json:"transcript"`
+	Confidence		float64			`json:"confidence"`
+}
+
 //A konuşmacısı kaç dakika konuştuğu gibi analizler 
 type SpeakerInfo struct {
 	SpeakerTag		int32			`json:"speaker_tag"`
-	TotalDuration 	float64			`json:"total_duration"`
+	Name			string			`json:"name,omitempty"`      // çok kanallı modda kanal adı (channel_names)
+	TotalDuration 	float64			`json:"total_duration"`      // konuşma sıralarının toplam süresi (saniye)
 	WordCount		int 			`json:"word_count"`
+	WordsPerMinute	float64			`json:"words_per_minute"`
+	Turns			int				`json:"turns"`               // söz alma sayısı
+	LongestMonologue float64		`json:"longest_monologue"`   // en uzun kesintisiz konuşma (saniye)
+	LongestMonologueStart float64	`json:"longest_monologue_start"`
+	Interruptions	int				`json:"interruptions"`       // başkasının sözünü kesme sayısı
+	Interrupted		int				`json:"interrupted"`         // sözü kesilme sayısı
+	TalkRatio		float64			`json:"talk_ratio"`          // toplam konuşma süresindeki payı (0-1)
 	Transcript		string			`json:"text_of_speaker"`
-
 }
 
 //istenen keywordün nerede geçtiğini bulmak için
 type KeywordMatch struct {
 	Keyword			string 			`json:"keyword"`
+	MatchedText		string			`json:"matched_text"`       // konuşmadaki hali (örn: "Sınav,")
 	Timestamp 		float64			`json:"timestamp"`
+	EndTime			float64			`json:"end_time"`
 	Context			string 			`json:"context"`
 	SpeakerTag		int32			`json:"speaker_tag,omitempty"` //opsiyonel
-
+	Distance		int				`json:"distance,omitempty"`    // bulanık eşleşmede harf farkı (0: tam eşleşme)
 }
-
-
-